@@ -0,0 +1,299 @@
+package datatable
+
+import (
+	"fmt"
+	"gonn/matrix"
+)
+
+// Table is the column API shared by DataTable and Columnar. Slices passed
+// in and returned are copies, so a table never aliases a caller's data.
+type Table interface {
+	Rows() int
+	Schema() Schema
+	AddRow(data []float64) error
+	AddRecord(values ...any) error
+	AddColumn(name string, data []float64) error
+	AddTypedColumn(f Field, values []any) error
+	SetColumn(name string, data []float64) error
+	RemoveColumn(name string) error
+	Column(name string) ([]float64, error)
+	ToMatrix(cols ...string) (*matrix.Matrix, error)
+}
+
+var (
+	_ Table = (*DataTable)(nil)
+	_ Table = (*Columnar)(nil)
+)
+
+// Columnar stores a table column-major, one contiguous slice per column, so
+// adding, removing and selecting columns costs O(columns) instead of
+// rewriting every row. It holds the same typed columns as DataTable, and
+// only ToMatrix lays the values out row-major for a model. The reader and
+// the DataTable queries produce row-major tables; convert with ToColumnar
+// before a run of column edits.
+type Columnar struct {
+	Cols    []string
+	Data    [][]float64
+	columns []*column
+	rows    int
+}
+
+func NewColumnar(schema Schema) *Columnar {
+	c := Columnar{
+		Cols:    make([]string, len(schema)),
+		Data:    make([][]float64, len(schema)),
+		columns: make([]*column, len(schema)),
+	}
+
+	for i, f := range schema {
+		c.Cols[i] = f.Name
+		c.columns[i] = newColumn(f.Type)
+	}
+
+	return &c
+}
+
+// ToColumnar copies the table into column-major storage.
+func (d *DataTable) ToColumnar() *Columnar {
+	m := d.Matrix
+	c := Columnar{
+		Cols:    make([]string, len(d.Cols)),
+		Data:    make([][]float64, len(d.Cols)),
+		columns: make([]*column, len(d.Cols)),
+		rows:    m.Rows,
+	}
+
+	copy(c.Cols, d.Cols)
+
+	for i := range d.Cols {
+		c.columns[i] = d.col(i).clone()
+		c.Data[i] = make([]float64, m.Rows)
+	}
+
+	for row := range m.Rows {
+		for i, s := range m.Data[row*m.Cols : (row+1)*m.Cols] {
+			c.Data[i][row] = s
+		}
+	}
+
+	return &c
+}
+
+// ToDataTable copies the columns into a row-major DataTable.
+func (c *Columnar) ToDataTable() *DataTable {
+	cols := make([]string, len(c.Cols))
+	copy(cols, c.Cols)
+
+	d := NewDataTable(cols)
+	d.Matrix = c.materialize(rowSpan(0, len(c.Cols)))
+
+	for i, col := range c.columns {
+		d.columns[i] = col.clone()
+	}
+
+	return d
+}
+
+func (c *Columnar) Rows() int {
+	return c.rows
+}
+
+func (c *Columnar) AddRow(data []float64) error {
+	if len(c.Cols) != len(data) {
+		return fmt.Errorf("mismatch between data length and columnar column length")
+	}
+
+	for i, s := range data {
+		c.Data[i] = append(c.Data[i], s)
+	}
+
+	c.rows++
+
+	return nil
+}
+
+// AddRecord appends a row of Go values as DataTable.AddRecord does.
+func (c *Columnar) AddRecord(values ...any) error {
+	if len(c.Cols) != len(values) {
+		return fmt.Errorf("mismatch between record length and columnar column length")
+	}
+
+	row := make([]float64, len(values))
+
+	for i, v := range values {
+		s, err := c.columns[i].encode(v)
+
+		if err != nil {
+			return fmt.Errorf("failed to add value to column %s: %w", c.Cols[i], err)
+		}
+
+		row[i] = s
+	}
+
+	return c.AddRow(row)
+}
+
+// AddColumn appends a copy of data as a Float column.
+func (c *Columnar) AddColumn(name string, data []float64) error {
+	return c.addColumn(name, append([]float64(nil), data...), newColumn(Float))
+}
+
+// addColumn appends a column that takes ownership of data.
+func (c *Columnar) addColumn(name string, data []float64, col *column) error {
+	if c.rows != len(data) && len(c.Cols) > 0 {
+		return fmt.Errorf("mismatch between columnar row count and data length")
+	}
+
+	c.rows = len(data)
+	c.Cols = append(c.Cols, name)
+	c.Data = append(c.Data, data)
+	c.columns = append(c.columns, col)
+
+	return nil
+}
+
+// AddTypedColumn appends a column of the given type built from Go values,
+// converted as in AddRecord.
+func (c *Columnar) AddTypedColumn(f Field, values []any) error {
+	col := newColumn(f.Type)
+	data := make([]float64, len(values))
+
+	for i, v := range values {
+		s, err := col.encode(v)
+
+		if err != nil {
+			return fmt.Errorf("failed to add value to column %s: %w", f.Name, err)
+		}
+
+		data[i] = s
+	}
+
+	return c.addColumn(f.Name, data, col)
+}
+
+// SetColumn replaces the values of a column with a copy of data. The
+// column becomes a Float column, as in DataTable.SetColumn.
+func (c *Columnar) SetColumn(name string, data []float64) error {
+	colIdx, err := findCol(c.Cols, name)
+
+	if err != nil {
+		return err
+	}
+
+	if c.rows != len(data) {
+		return fmt.Errorf("mismatch between columnar row count and data length")
+	}
+
+	c.Data[colIdx] = append([]float64(nil), data...)
+	c.columns[colIdx] = newColumn(Float)
+
+	return nil
+}
+
+func (c *Columnar) RemoveColumn(name string) error {
+	colIdx, err := findCol(c.Cols, name)
+
+	if err != nil {
+		return err
+	}
+
+	c.Cols = append(c.Cols[:colIdx], c.Cols[colIdx+1:]...)
+	c.Data = append(c.Data[:colIdx], c.Data[colIdx+1:]...)
+	c.columns = append(c.columns[:colIdx], c.columns[colIdx+1:]...)
+
+	return nil
+}
+
+// Column returns a copy of the stored values of a column.
+func (c *Columnar) Column(name string) ([]float64, error) {
+	colIdx, err := findCol(c.Cols, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]float64(nil), c.Data[colIdx]...), nil
+}
+
+// Select returns a table of the named columns that shares their values
+// with c. Rows added to either table afterwards are not shared.
+func (c *Columnar) Select(cols ...string) (*Columnar, error) {
+	s := Columnar{
+		Cols:    make([]string, len(cols)),
+		Data:    make([][]float64, len(cols)),
+		columns: make([]*column, len(cols)),
+		rows:    c.rows,
+	}
+
+	for i, name := range cols {
+		colIdx, err := findCol(c.Cols, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		v := c.Data[colIdx]
+
+		s.Cols[i] = c.Cols[colIdx]
+		s.Data[i] = v[:len(v):len(v)]
+		s.columns[i] = c.columns[colIdx].clone()
+	}
+
+	return &s, nil
+}
+
+func (c *Columnar) Schema() Schema {
+	schema := make(Schema, len(c.Cols))
+
+	for i, name := range c.Cols {
+		schema[i] = Field{
+			Name: name,
+			Type: c.columns[i].typ,
+		}
+	}
+
+	return schema
+}
+
+// ToMatrix returns the named columns (all columns when none are given) as
+// a new row-major matrix for model input, with the same type rules as
+// DataTable.ToMatrix.
+func (c *Columnar) ToMatrix(cols ...string) (*matrix.Matrix, error) {
+	if len(cols) == 0 {
+		cols = c.Cols
+	}
+
+	idxs := make([]int, len(cols))
+
+	for i, name := range cols {
+		colIdx, err := findCol(c.Cols, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if c.columns[colIdx].typ == String {
+			return nil, fmt.Errorf("column %s has type string and cannot be used as model input", name)
+		}
+
+		idxs[i] = colIdx
+	}
+
+	return c.materialize(idxs), nil
+}
+
+func (c *Columnar) materialize(idxs []int) *matrix.Matrix {
+	m, err := matrix.NewMatrix(c.rows, len(idxs))
+
+	if err != nil {
+		panic("creating a new matrix failed during columnar materialize resulted in fatal error")
+	}
+
+	for i, colIdx := range idxs {
+		for row, s := range c.Data[colIdx] {
+			m.Data[row*m.Cols+i] = s
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,153 @@
+package datatable
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestColumnar(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+	c := d.ToColumnar()
+
+	if c.Rows() != 3 || len(c.Cols) != 6 {
+		t.Fatalf("expected 3 rows and 6 columns, got %d and %d", c.Rows(), len(c.Cols))
+	}
+
+	err := c.AddRecord(1.5, 2, true, "fourth", "Versicolor", "2024-03-01")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	err = c.AddColumn("extra", []float64{1, 2, 3, 4})
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	err = c.AddColumn("short", []float64{1})
+
+	if err == nil {
+		t.Errorf("expected error for mismatched length, got nil")
+	}
+
+	err = c.RemoveColumn("note")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	s, err := c.Select("variety", "extra")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	m, err := s.ToMatrix()
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	exp := []float64{0, 1, 1, 2, 0, 3, 2, 4}
+
+	for i, v := range exp {
+		if m.Data[i] != v {
+			t.Fatalf("expected row-major data %v, got %v", exp, m.Data)
+		}
+	}
+
+	// Appending to the selection leaves the source columns alone
+	_ = s.AddRow([]float64{0, 100})
+
+	v, _ := c.Column("extra")
+
+	if len(v) != 4 {
+		t.Errorf("expected %d values in source column, got %d", 4, len(v))
+	}
+
+	back := c.ToDataTable()
+
+	label, err := back.StringAt(3, "variety")
+
+	if err != nil || label != "Versicolor" {
+		t.Errorf("expected variety %v, got %v (%v)", "Versicolor", label, err)
+	}
+
+	if len(back.Schema()) != 6 || back.Schema()[5].Name != "extra" {
+		t.Errorf("expected schema to round trip, got %v", back.Schema())
+	}
+}
+
+func benchmarkAddColumns(b *testing.B, add func(name string, data []float64) error) {
+	data := make([]float64, 10000)
+
+	for i := range 50 {
+		err := add("c"+strconv.Itoa(i), data)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAddColumnDataTable(b *testing.B) {
+	for b.Loop() {
+		d := NewDataTable([]string{})
+		d.Matrix.Rows = 10000
+
+		benchmarkAddColumns(b, d.AddColumn)
+	}
+}
+
+func BenchmarkAddColumnColumnar(b *testing.B) {
+	for b.Loop() {
+		c := NewColumnar(Schema{})
+
+		benchmarkAddColumns(b, c.AddColumn)
+	}
+}
+
+func TestTableCopies(t *testing.T) {
+	for name, tbl := range map[string]Table{
+		"datatable": NewDataTable([]string{"a"}),
+		"columnar":  NewColumnar(Schema{{Name: "a", Type: Float}}),
+	} {
+		_ = tbl.AddRow([]float64{1})
+		_ = tbl.AddRow([]float64{2})
+
+		data := []float64{3, 4}
+
+		err := tbl.AddColumn("b", data)
+
+		if err != nil {
+			t.Fatalf("%s: expected no error, got error: %v", name, err)
+		}
+
+		data[0] = 100
+
+		v, _ := tbl.Column("b")
+		v[1] = 100
+
+		err = tbl.SetColumn("a", data)
+
+		if err != nil {
+			t.Fatalf("%s: expected no error, got error: %v", name, err)
+		}
+
+		data[1] = 100
+
+		_ = tbl.RemoveColumn("a")
+		_ = tbl.AddColumn("a", data)
+
+		m, err := tbl.ToMatrix("b")
+
+		if err != nil {
+			t.Fatalf("%s: expected no error, got error: %v", name, err)
+		}
+
+		if tbl.Rows() != 2 || m.Data[0] != 3 || m.Data[1] != 4 {
+			t.Errorf("%s: expected column b to keep %v, got %v", name, []float64{3, 4}, m.Data)
+		}
+	}
+}
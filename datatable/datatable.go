@@ -3,13 +3,13 @@ package datatable
 import (
 	"fmt"
 	"gonn/matrix"
-	"strconv"
 	"strings"
 )
 
 type DataTable struct {
-	Matrix *matrix.Matrix
-	Cols   []string
+	Matrix  *matrix.Matrix
+	Cols    []string
+	columns []*column
 }
 
 func NewDataTable(cols []string) *DataTable {
@@ -20,8 +20,13 @@ func NewDataTable(cols []string) *DataTable {
 	}
 
 	d := DataTable{
-		Matrix: m,
-		Cols:   cols,
+		Matrix:  m,
+		Cols:    cols,
+		columns: make([]*column, len(cols)),
+	}
+
+	for i := range d.columns {
+		d.columns[i] = newColumn(Float)
 	}
 
 	return &d
@@ -41,6 +46,9 @@ func (d *DataTable) AddRow(data []float64) error {
 	return nil
 }
 
+// AddColumn appends a Float column holding a copy of data. Row-major data
+// is rebuilt to make room, so this is O(rows*cols); build wide tables with
+// Columnar and convert once with ToDataTable.
 func (d *DataTable) AddColumn(name string, data []float64) error {
 	m := d.Matrix
 
@@ -48,54 +56,145 @@ func (d *DataTable) AddColumn(name string, data []float64) error {
 		return fmt.Errorf("mismatch between datatable row count and data length")
 	}
 
-	m.Cols++
-	d.Cols = append(d.Cols, name)
+	// Rebuild the row-major data in one pass rather than shifting every
+	// row to make room
+	newData := make([]float64, 0, (m.Cols+1)*m.Rows)
 
 	for i, s := range data {
-		m.Data = append(m.Data, 0)
-		mIdx := i*m.Cols + (m.Cols - 1)
-
-		copy(m.Data[mIdx+1:], m.Data[mIdx:])
-
-		m.Data[mIdx] = s
+		newData = append(newData, m.Data[i*m.Cols:(i+1)*m.Cols]...)
+		newData = append(newData, s)
 	}
 
+	m.Data = newData
+	m.Cols++
+	d.syncColumns()
+	d.Cols = append(d.Cols, name)
+	d.columns = append(d.columns, newColumn(Float))
+
 	return nil
 }
 
+// RemoveColumn drops a column. Row-major data moves every row, so this is
+// O(rows*cols); Columnar removes a column in O(columns).
 func (d *DataTable) RemoveColumn(name string) error {
-	// Find the index of the column to remove
 	colIdx, err := d.findColIdx(name)
+
 	if err != nil {
 		return err
 	}
 
-	// Remove the column name from Cols slice
+	d.syncColumns()
 	d.Cols = append(d.Cols[:colIdx], d.Cols[colIdx+1:]...)
+	d.columns = append(d.columns[:colIdx], d.columns[colIdx+1:]...)
 
-	// Remove the column data from the matrix
+	// Copy the kept span on each side of the column per row into new data,
+	// leaving tables that share the old data through RowRange intact
 	m := d.Matrix
-	newData := make([]float64, 0, (m.Cols-1)*m.Rows)
+	newData := make([]float64, (m.Cols-1)*m.Rows)
+	n := 0
 
-	for row := 0; row < m.Rows; row++ {
-		for col := 0; col < m.Cols; col++ {
-			if col == colIdx {
-				continue // Skip the column we're removing
-			}
-			idx := row*m.Cols + col
-			newData = append(newData, m.Data[idx])
-		}
+	for row := range m.Rows {
+		src := m.Data[row*m.Cols : (row+1)*m.Cols]
+		n += copy(newData[n:], src[:colIdx])
+		n += copy(newData[n:], src[colIdx+1:])
 	}
 
-	// Update the matrix
 	m.Data = newData
 	m.Cols--
 
 	return nil
 }
 
+func (d *DataTable) Rows() int {
+	return d.Matrix.Rows
+}
+
+// Column returns a copy of the stored values of a column.
+func (d *DataTable) Column(name string) ([]float64, error) {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Matrix.SliceCol(colIdx)
+}
+
+// SetColumn overwrites the values of a column in place. The column becomes
+// a Float column, since the new values need not be codes or integers.
+func (d *DataTable) SetColumn(name string, data []float64) error {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return err
+	}
+
+	m := d.Matrix
+
+	if m.Rows != len(data) {
+		return fmt.Errorf("mismatch between datatable row count and data length")
+	}
+
+	for row, s := range data {
+		m.Data[row*m.Cols+colIdx] = s
+	}
+
+	d.syncColumns()
+	d.columns[colIdx] = newColumn(Float)
+
+	return nil
+}
+
+// RowRange returns rows [start, end) as a DataTable that shares the
+// underlying matrix data instead of copying it.
+func (d *DataTable) RowRange(start, end int) (*DataTable, error) {
+	m, err := d.Matrix.RowRange(start, end)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get datatable row range: %w", err)
+	}
+
+	cols := make([]string, len(d.Cols))
+	copy(cols, d.Cols)
+
+	columns := make([]*column, len(d.columns))
+	copy(columns, d.columns)
+
+	return &DataTable{
+		Matrix:  m,
+		Cols:    cols,
+		columns: columns,
+	}, nil
+}
+
+// col returns the type of column i. A table built as a struct literal has
+// no column types, and its columns read as Float.
+func (d *DataTable) col(i int) *column {
+	return columnAt(d.columns, i)
+}
+
+func columnAt(columns []*column, i int) *column {
+	if i < len(columns) && columns[i] != nil {
+		return columns[i]
+	}
+
+	return newColumn(Float)
+}
+
+// syncColumns pads the column types to one per column before they are
+// changed alongside Cols.
+func (d *DataTable) syncColumns() {
+	for len(d.columns) < len(d.Cols) {
+		d.columns = append(d.columns, newColumn(Float))
+	}
+}
+
 func (d *DataTable) findColIdx(name string) (int, error) {
-	for i, col := range d.Cols {
+	return findCol(d.Cols, name)
+}
+
+func findCol(cols []string, name string) (int, error) {
+	for i, col := range cols {
 		if strings.EqualFold(col, name) {
 			return i, nil
 		}
@@ -138,7 +237,7 @@ func (d *DataTable) String() string {
 				panic("failed to get value of matrix for String print method")
 			}
 
-			builder.WriteString(strconv.FormatFloat(v, 'f', 2, 64))
+			builder.WriteString(d.col(col).format(v))
 		}
 		builder.WriteString("]\n")
 	}
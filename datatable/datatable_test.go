@@ -1,9 +1,28 @@
 package datatable
 
 import (
+	"gonn/matrix"
 	"testing"
 )
 
+// newTable builds a table with the given schema from rows of Go values, as
+// AddRecord takes them.
+func newTable(t *testing.T, schema Schema, rows [][]any) *DataTable {
+	t.Helper()
+
+	d := NewDataTableWithSchema(schema)
+
+	for _, r := range rows {
+		err := d.AddRecord(r...)
+
+		if err != nil {
+			t.Fatalf("expected no error, got error: %v", err)
+		}
+	}
+
+	return d
+}
+
 func TestNewDataTable(t *testing.T) {
 	cols := []string{"a", "b", "c"}
 
@@ -200,3 +219,124 @@ func TestRemoveColumn(t *testing.T) {
 		t.Errorf("expected %f matrix value for (%d,%d), got %f", float64(2), 0, 1, v)
 	}
 }
+
+func TestRowRange(t *testing.T) {
+	d := NewDataTable([]string{"a", "b"})
+
+	_ = d.AddRow([]float64{1, 2})
+	_ = d.AddRow([]float64{3, 4})
+	_ = d.AddRow([]float64{5, 6})
+
+	r, err := d.RowRange(1, 3)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if r.Matrix.Rows != 2 {
+		t.Errorf("expected %d rows, got %d", 2, r.Matrix.Rows)
+	}
+
+	_ = r.Matrix.Set(0, 1, 9)
+
+	if v, _ := d.Matrix.At(1, 1); v != 9 {
+		t.Errorf("expected row range to share data, got %f", v)
+	}
+
+	err = r.AddRow([]float64{7, 8})
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if len(d.Matrix.Data) != 6 {
+		t.Errorf("expected source data length %d, got %d", 6, len(d.Matrix.Data))
+	}
+
+	_, err = d.RowRange(0, 4)
+
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestSetColumn(t *testing.T) {
+	d := NewDataTableWithSchema(Schema{{Name: "a", Type: Int}, {Name: "b", Type: Float}})
+
+	_ = d.AddRecord(1, 2.0)
+	_ = d.AddRecord(3, 4.0)
+
+	err := d.SetColumn("A", []float64{0.5, 1.5})
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	col, err := d.Column("a")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if col[0] != 0.5 || col[1] != 1.5 {
+		t.Errorf("expected column %v, got %v", []float64{0.5, 1.5}, col)
+	}
+
+	typ, _ := d.ColumnType("a")
+
+	if typ != Float {
+		t.Errorf("expected %v column type, got %v", Float, typ)
+	}
+
+	err = d.SetColumn("a", []float64{1})
+
+	if err == nil {
+		t.Errorf("expected error for mismatched length, got nil")
+	}
+}
+
+func TestStructLiteral(t *testing.T) {
+	m := &matrix.Matrix{Rows: 2, Cols: 2, Data: []float64{1, 2, 3, 4}}
+	d := &DataTable{Matrix: m, Cols: []string{"a", "b"}}
+
+	typ, err := d.ColumnType("b")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if typ != Float {
+		t.Errorf("expected column type %s, got %s", Float, typ)
+	}
+
+	_ = d.String()
+	_ = d.Describe()
+
+	s, err := d.Select("b")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if s.Schema()[0].Type != Float {
+		t.Errorf("expected selected column type %s, got %s", Float, s.Schema()[0].Type)
+	}
+
+	err = d.AddTypedColumn(Field{Name: "c", Type: Bool}, []any{true, false})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err = d.RemoveColumn("a")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	schema := d.Schema()
+
+	if len(schema) != 2 || schema[0].Type != Float || schema[1].Type != Bool {
+		t.Errorf("expected schema [b:%s c:%s], got %v", Float, Bool, schema)
+	}
+}
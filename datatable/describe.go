@@ -0,0 +1,296 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"gonn/matrix"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ColumnSummary describes the values of one column. Count excludes missing
+// cells. The numeric statistics are NaN for String and Categorical columns,
+// and Std is the sample standard deviation.
+type ColumnSummary struct {
+	Name    string
+	Type    ColumnType
+	Count   int
+	Missing int
+	Unique  int
+	Mean    float64
+	Std     float64
+	Min     float64
+	Q1      float64
+	Median  float64
+	Q3      float64
+	Max     float64
+}
+
+type Summary []ColumnSummary
+
+// Describe summarizes every column of the table.
+func (d *DataTable) Describe() Summary {
+	summary := make(Summary, len(d.Cols))
+
+	for i, name := range d.Cols {
+		v, err := d.Matrix.SliceCol(i)
+
+		if err != nil {
+			panic("failed to get column of matrix for Describe method")
+		}
+
+		s := ColumnSummary{
+			Name: name,
+			Type: d.col(i).typ,
+		}
+
+		present := make([]float64, 0, len(v))
+		unique := make(map[float64]struct{})
+
+		for _, x := range v {
+			if math.IsNaN(x) {
+				s.Missing++
+				continue
+			}
+
+			present = append(present, x)
+			unique[x] = struct{}{}
+		}
+
+		s.Count = len(present)
+		s.Unique = len(unique)
+		s.Mean, s.Std, s.Min, s.Q1, s.Median, s.Q3, s.Max = NA(), NA(), NA(), NA(), NA(), NA(), NA()
+
+		if !d.col(i).hasLevels() && len(present) > 0 {
+			sort.Float64s(present)
+
+			sum := 0.0
+			for _, x := range present {
+				sum += x
+			}
+			s.Mean = sum / float64(len(present))
+
+			if len(present) > 1 {
+				ss := 0.0
+				for _, x := range present {
+					ss += (x - s.Mean) * (x - s.Mean)
+				}
+				s.Std = math.Sqrt(ss / float64(len(present)-1))
+			}
+
+			s.Min = present[0]
+			s.Q1 = Quantile(present, 0.25)
+			s.Median = Quantile(present, 0.5)
+			s.Q3 = Quantile(present, 0.75)
+			s.Max = present[len(present)-1]
+		}
+
+		summary[i] = s
+	}
+
+	return summary
+}
+
+// String renders the summary with one row per column.
+func (s Summary) String() string {
+	var builder strings.Builder
+
+	w := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(w, "column\ttype\tcount\tmissing\tunique\tmean\tstd\tmin\t25%\t50%\t75%\tmax\t")
+
+	for _, c := range s {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d", c.Name, c.Type, c.Count, c.Missing, c.Unique)
+
+		for _, v := range []float64{c.Mean, c.Std, c.Min, c.Q1, c.Median, c.Q3, c.Max} {
+			fmt.Fprintf(w, "\t%s", formatStat(v))
+		}
+
+		fmt.Fprintln(w, "\t")
+	}
+
+	_ = w.Flush()
+
+	return builder.String()
+}
+
+func formatStat(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// Covariance returns the sample covariance matrix of the named columns, or
+// of every numeric column when none are given, over the rows with no
+// missing value in any of them.
+func (d *DataTable) Covariance(cols ...string) (*matrix.Matrix, error) {
+	x, err := d.completeNumeric(cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute covariance: %w", err)
+	}
+
+	if x.Rows < 2 {
+		return nil, errors.New("failed to compute covariance: fewer than 2 complete rows")
+	}
+
+	means := x.ColMeans()
+	cov, _ := matrix.NewMatrix(x.Cols, x.Cols)
+
+	for row := range x.Rows {
+		r := x.Data[row*x.Cols : (row+1)*x.Cols]
+
+		for i := range x.Cols {
+			di := r[i] - means[i]
+
+			for j := i; j < x.Cols; j++ {
+				cov.Data[i*x.Cols+j] += di * (r[j] - means[j])
+			}
+		}
+	}
+
+	for i := range x.Cols {
+		for j := i; j < x.Cols; j++ {
+			v := cov.Data[i*x.Cols+j] / float64(x.Rows-1)
+			cov.Data[i*x.Cols+j] = v
+			cov.Data[j*x.Cols+i] = v
+		}
+	}
+
+	return cov, nil
+}
+
+// Correlation returns the Pearson correlation matrix of the named columns,
+// computed like Covariance. Constant columns correlate as NaN.
+func (d *DataTable) Correlation(cols ...string) (*matrix.Matrix, error) {
+	cov, err := d.Covariance(cols...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	n := cov.Cols
+	corr, _ := matrix.NewMatrix(n, n)
+
+	for i := range n {
+		for j := range n {
+			corr.Data[i*n+j] = cov.Data[i*n+j] / math.Sqrt(cov.Data[i*n+i]*cov.Data[j*n+j])
+		}
+	}
+
+	return corr, nil
+}
+
+// completeNumeric returns the named columns as a matrix of the rows with no
+// missing values. String columns are rejected; Categorical level codes
+// have no order, so they are rejected too.
+func (d *DataTable) completeNumeric(cols []string) (*matrix.Matrix, error) {
+	if len(cols) == 0 {
+		for i, name := range d.Cols {
+			if !d.col(i).hasLevels() {
+				cols = append(cols, name)
+			}
+		}
+	}
+
+	for _, name := range cols {
+		typ, err := d.ColumnType(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if typ == String || typ == Categorical {
+			return nil, fmt.Errorf("column %s has type %s", name, typ)
+		}
+	}
+
+	if len(cols) == 0 {
+		return nil, errors.New("no numeric columns")
+	}
+
+	complete, err := d.DropMissing(cols...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return complete.ToMatrix(cols...)
+}
+
+// ValueCount is the number of rows holding one value of a column.
+type ValueCount struct {
+	Value string
+	Count int
+}
+
+// ValueCounts returns how often each value of a column occurs, most common
+// first, with ties in order of the value. Missing cells are not counted.
+func (d *DataTable) ValueCounts(name string) ([]ValueCount, error) {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := d.col(colIdx)
+	m := d.Matrix
+	counts := make(map[float64]int)
+
+	for row := range m.Rows {
+		v := m.Data[row*m.Cols+colIdx]
+
+		if !math.IsNaN(v) {
+			counts[v]++
+		}
+	}
+
+	keys := make([]float64, 0, len(counts))
+	for v := range counts {
+		keys = append(keys, v)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+
+		if c.hasLevels() {
+			return c.format(keys[i]) < c.format(keys[j])
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	result := make([]ValueCount, len(keys))
+
+	for i, v := range keys {
+		label := c.format(v)
+
+		if c.typ == Float {
+			label = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+
+		result[i] = ValueCount{
+			Value: label,
+			Count: counts[v],
+		}
+	}
+
+	return result, nil
+}
+
+// Quantile linearly interpolates the q-th quantile, 0 <= q <= 1, of
+// sorted values.
+func Quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := min(lo+1, len(sorted)-1)
+
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
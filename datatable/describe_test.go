@@ -0,0 +1,133 @@
+package datatable
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	d := NewDataTableWithSchema(Schema{
+		{Name: "x", Type: Float},
+		{Name: "variety", Type: Categorical},
+	})
+
+	for _, r := range [][]any{{1.0, "a"}, {2.0, "b"}, {nil, "a"}, {3.0, nil}, {4.0, "a"}} {
+		err := d.AddRecord(r...)
+
+		if err != nil {
+			t.Fatalf("expected no error, got error: %v", err)
+		}
+	}
+
+	s := d.Describe()
+
+	x := s[0]
+
+	if x.Count != 4 || x.Missing != 1 || x.Unique != 4 {
+		t.Errorf("expected count 4, missing 1, unique 4, got %d, %d, %d", x.Count, x.Missing, x.Unique)
+	}
+
+	for _, c := range []struct {
+		name     string
+		got, exp float64
+	}{
+		{"mean", x.Mean, 2.5},
+		{"std", x.Std, math.Sqrt(5.0 / 3)},
+		{"min", x.Min, 1},
+		{"q1", x.Q1, 1.75},
+		{"median", x.Median, 2.5},
+		{"q3", x.Q3, 3.25},
+		{"max", x.Max, 4},
+	} {
+		if math.Abs(c.got-c.exp) > 1e-12 {
+			t.Errorf("expected %s %v, got %v", c.name, c.exp, c.got)
+		}
+	}
+
+	v := s[1]
+
+	if v.Count != 4 || v.Missing != 1 || v.Unique != 2 || !math.IsNaN(v.Mean) {
+		t.Errorf("expected categorical count 4, missing 1, unique 2 and no mean, got %+v", v)
+	}
+
+	report := s.String()
+
+	if !strings.Contains(report, "variety") || !strings.Contains(report, "2.5") {
+		t.Errorf("expected report to list columns and statistics, got\n%s", report)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	d := NewDataTable([]string{"a", "b", "c"})
+
+	for _, r := range [][]float64{{1, 2, 3}, {2, 4, 1}, {3, 6, 2}, {math.NaN(), 0, 0}} {
+		_ = d.AddRow(r)
+	}
+
+	cov, err := d.Covariance("a", "b")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	for i, exp := range []float64{1, 2, 2, 4} {
+		if math.Abs(cov.Data[i]-exp) > 1e-12 {
+			t.Fatalf("expected covariance %v, got %v", []float64{1, 2, 2, 4}, cov.Data)
+		}
+	}
+
+	corr, err := d.Correlation()
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if corr.Rows != 3 || math.Abs(corr.Data[1]-1) > 1e-12 || math.Abs(corr.Data[2]+0.5) > 1e-12 {
+		t.Errorf("expected correlations 1 and -0.5, got %v", corr.Data)
+	}
+}
+
+func TestValueCounts(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	counts, err := d.ValueCounts("variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	exp := []ValueCount{{"Setosa", 2}, {"Virginica", 1}}
+
+	if len(counts) != len(exp) || counts[0] != exp[0] || counts[1] != exp[1] {
+		t.Errorf("expected value counts %v, got %v", exp, counts)
+	}
+
+	counts, _ = d.ValueCounts("ok")
+
+	if len(counts) != 2 || counts[0] != (ValueCount{"false", 2}) {
+		t.Errorf("expected 2 false values first, got %v", counts)
+	}
+}
+
+func TestValueCountsInvalidCode(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	err := d.Matrix.Set(2, 4, 7)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	counts, err := d.ValueCounts("variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	exp := []ValueCount{{"?", 1}, {"Setosa", 1}, {"Virginica", 1}}
+
+	if len(counts) != len(exp) || counts[0] != exp[0] || counts[1] != exp[1] || counts[2] != exp[2] {
+		t.Errorf("expected value counts %v, got %v", exp, counts)
+	}
+}
@@ -0,0 +1,279 @@
+package datatable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Reducer folds the present (non-missing) values of one group into a
+// single value.
+type Reducer func(v []float64) float64
+
+// Aggregation reduces one column per group into an output column. The
+// output is a Float column unless Type says otherwise.
+type Aggregation struct {
+	Col  string
+	Name string
+	Type ColumnType
+	Fn   Reducer
+
+	// keepType makes the output a copy of the source column, so First and
+	// Last of a Categorical column stay Categorical
+	keepType bool
+}
+
+// Agg aggregates col into a column called name with a custom reducer.
+func Agg(col, name string, fn Reducer) Aggregation {
+	return Aggregation{Col: col, Name: name, Fn: fn}
+}
+
+func Sum(col string) Aggregation {
+	return Agg(col, col+"_sum", func(v []float64) float64 {
+		sum := 0.0
+		for _, x := range v {
+			sum += x
+		}
+
+		return sum
+	})
+}
+
+func Mean(col string) Aggregation {
+	return Agg(col, col+"_mean", mean)
+}
+
+func Min(col string) Aggregation {
+	return Agg(col, col+"_min", func(v []float64) float64 {
+		if len(v) == 0 {
+			return NA()
+		}
+
+		lo := v[0]
+		for _, x := range v {
+			lo = min(lo, x)
+		}
+
+		return lo
+	})
+}
+
+func Max(col string) Aggregation {
+	return Agg(col, col+"_max", func(v []float64) float64 {
+		if len(v) == 0 {
+			return NA()
+		}
+
+		hi := v[0]
+		for _, x := range v {
+			hi = max(hi, x)
+		}
+
+		return hi
+	})
+}
+
+// Count is the number of present values in the group.
+func Count(col string) Aggregation {
+	a := Agg(col, col+"_count", func(v []float64) float64 {
+		return float64(len(v))
+	})
+	a.Type = Int
+
+	return a
+}
+
+// Std is the sample standard deviation, NaN for groups of one value.
+func Std(col string) Aggregation {
+	return Agg(col, col+"_std", func(v []float64) float64 {
+		if len(v) < 2 {
+			return NA()
+		}
+
+		m := mean(v)
+		ss := 0.0
+		for _, x := range v {
+			ss += (x - m) * (x - m)
+		}
+
+		return math.Sqrt(ss / float64(len(v)-1))
+	})
+}
+
+func First(col string) Aggregation {
+	a := Agg(col, col+"_first", func(v []float64) float64 {
+		if len(v) == 0 {
+			return NA()
+		}
+
+		return v[0]
+	})
+	a.keepType = true
+
+	return a
+}
+
+func Last(col string) Aggregation {
+	a := Agg(col, col+"_last", func(v []float64) float64 {
+		if len(v) == 0 {
+			return NA()
+		}
+
+		return v[len(v)-1]
+	})
+	a.keepType = true
+
+	return a
+}
+
+func mean(v []float64) float64 {
+	if len(v) == 0 {
+		return NA()
+	}
+
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+
+	return sum / float64(len(v))
+}
+
+// Grouped is a DataTable split into groups of rows sharing the values of
+// the key columns, in order of each group's first row.
+type Grouped struct {
+	table  *DataTable
+	keys   []int
+	groups [][]int
+}
+
+// GroupBy groups the rows by the values of the named columns. Missing key
+// values form a group of their own.
+func (d *DataTable) GroupBy(cols ...string) (*Grouped, error) {
+	if len(cols) == 0 {
+		return nil, errors.New("group by requires at least one column")
+	}
+
+	keys := make([]int, len(cols))
+
+	for i, name := range cols {
+		colIdx, err := d.findColIdx(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys[i] = colIdx
+	}
+
+	m := d.Matrix
+	index := make(map[string]int)
+	groups := make([][]int, 0)
+	buf := make([]byte, 8*len(keys))
+
+	for row := range m.Rows {
+		for i, colIdx := range keys {
+			binary.LittleEndian.PutUint64(buf[i*8:], groupKey(m.Data[row*m.Cols+colIdx]))
+		}
+
+		g, ok := index[string(buf)]
+
+		if !ok {
+			g = len(groups)
+			index[string(buf)] = g
+			groups = append(groups, nil)
+		}
+
+		groups[g] = append(groups[g], row)
+	}
+
+	return &Grouped{
+		table:  d,
+		keys:   keys,
+		groups: groups,
+	}, nil
+}
+
+// groupKey returns the bits a value groups by. Negative and positive zero
+// share a key, and every NaN bit pattern is the same missing key.
+func groupKey(v float64) uint64 {
+	if math.IsNaN(v) {
+		return math.Float64bits(NA())
+	}
+
+	return math.Float64bits(v + 0)
+}
+
+// Len returns the number of groups.
+func (g *Grouped) Len() int {
+	return len(g.groups)
+}
+
+// Agg returns a table with one row per group: the key columns followed by
+// one column per aggregation.
+func (g *Grouped) Agg(aggs ...Aggregation) (*DataTable, error) {
+	d := g.table
+	srcs := make([]int, len(aggs))
+
+	for i, a := range aggs {
+		if a.Fn == nil {
+			return nil, fmt.Errorf("aggregation %s has no reducer", a.Name)
+		}
+
+		colIdx, err := d.findColIdx(a.Col)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate %s: %w", a.Name, err)
+		}
+
+		srcs[i] = colIdx
+	}
+
+	result := d.emptyLike(g.keys)
+
+	for i, a := range aggs {
+		c := newColumn(a.Type)
+
+		if a.keepType {
+			c = d.col(srcs[i]).clone()
+		}
+
+		result.Cols = append(result.Cols, a.Name)
+		result.columns = append(result.columns, c)
+	}
+
+	result.Matrix.Cols = len(result.Cols)
+
+	m := d.Matrix
+	row := make([]float64, len(result.Cols))
+	values := make([]float64, 0)
+
+	for _, rows := range g.groups {
+		for i, colIdx := range g.keys {
+			row[i] = m.Data[rows[0]*m.Cols+colIdx]
+		}
+
+		for i, colIdx := range srcs {
+			values = values[:0]
+
+			for _, r := range rows {
+				v := m.Data[r*m.Cols+colIdx]
+
+				if !math.IsNaN(v) {
+					values = append(values, v)
+				}
+			}
+
+			row[len(g.keys)+i] = aggs[i].Fn(values)
+		}
+
+		err := result.AddRow(row)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate: %w", err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package datatable
+
+import (
+	"math"
+	"testing"
+)
+
+// ordersSchema and ordersRows hold orders by customer and store, with one
+// missing amount.
+var ordersSchema = Schema{
+	{Name: "customer", Type: Categorical},
+	{Name: "store", Type: Int},
+	{Name: "amount", Type: Float},
+}
+
+var ordersRows = [][]any{
+	{"ann", 1, 10.0},
+	{"bob", 1, 5.0},
+	{"ann", 2, 30.0},
+	{"ann", 1, nil},
+	{"bob", 1, 7.0},
+	{"cat", 2, 1.0},
+}
+
+func TestGroupByAgg(t *testing.T) {
+	d := newTable(t, ordersSchema, ordersRows)
+
+	g, err := d.GroupBy("customer")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if g.Len() != 3 {
+		t.Fatalf("expected %d groups, got %d", 3, g.Len())
+	}
+
+	out, err := g.Agg(Sum("amount"), Mean("amount"), Min("amount"), Max("amount"),
+		Count("amount"), Std("amount"), First("store"), Last("amount"),
+		Agg("amount", "amount_range", func(v []float64) float64 {
+			return v[len(v)-1] - v[0]
+		}))
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	exp := map[string][]float64{
+		"amount_sum":   {40, 12, 1},
+		"amount_mean":  {20, 6, 1},
+		"amount_min":   {10, 5, 1},
+		"amount_max":   {30, 7, 1},
+		"amount_count": {2, 2, 1},
+		"amount_std":   {math.Sqrt(200), math.Sqrt(2), math.NaN()},
+		"store_first":  {1, 1, 2},
+		"amount_last":  {30, 7, 1},
+		"amount_range": {20, 2, 0},
+	}
+
+	for name, values := range exp {
+		for row, e := range values {
+			v, err := out.FloatAt(row, name)
+
+			if err != nil {
+				t.Fatalf("expected no error, got error: %v", err)
+			}
+
+			if !(math.Abs(v-e) < 1e-12 || math.IsNaN(v) && math.IsNaN(e)) {
+				t.Errorf("expected %s %v in row %d, got %v", name, e, row, v)
+			}
+		}
+	}
+
+	for row, e := range []string{"ann", "bob", "cat"} {
+		s, _ := out.StringAt(row, "customer")
+
+		if s != e {
+			t.Errorf("expected customer %v in row %d, got %v", e, row, s)
+		}
+	}
+
+	typ, _ := out.ColumnType("amount_count")
+
+	if typ != Int {
+		t.Errorf("expected %v count column, got %v", Int, typ)
+	}
+
+	typ, _ = out.ColumnType("store_first")
+
+	if typ != Int {
+		t.Errorf("expected first to keep the %v column type, got %v", Int, typ)
+	}
+}
+
+func TestGroupByMultipleKeys(t *testing.T) {
+	d := newTable(t, ordersSchema, ordersRows)
+
+	g, err := d.GroupBy("customer", "store")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	out, err := g.Agg(Count("amount"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if out.Matrix.Rows != 4 {
+		t.Fatalf("expected %d groups, got %d", 4, out.Matrix.Rows)
+	}
+
+	// ann at store 1 has one present amount and one missing
+	v, _ := out.IntAt(0, "amount_count")
+
+	if v != 1 {
+		t.Errorf("expected count %d, got %d", 1, v)
+	}
+
+	_, err = g.Agg(Sum("missing"))
+
+	if err == nil {
+		t.Errorf("expected error for missing column, got nil")
+	}
+}
+
+func TestGroupBySignedZero(t *testing.T) {
+	d := NewDataTable([]string{"key", "value"})
+	_ = d.AddRow([]float64{0, 1})
+	_ = d.AddRow([]float64{math.Copysign(0, -1), 2})
+	_ = d.AddRow([]float64{math.NaN(), 3})
+	_ = d.AddRow([]float64{NA(), 4})
+
+	g, err := d.GroupBy("key")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	out, err := g.Agg(Sum("value"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if out.Matrix.Rows != 2 {
+		t.Fatalf("expected %d groups, got %d", 2, out.Matrix.Rows)
+	}
+
+	v, _ := out.Matrix.At(0, 1)
+
+	if v != 3 {
+		t.Errorf("expected sum %f for the zero group, got %f", 3.0, v)
+	}
+}
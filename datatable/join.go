@@ -0,0 +1,239 @@
+package datatable
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type JoinType int
+
+const (
+	InnerJoin JoinType = iota
+	LeftJoin
+	RightJoin
+	OuterJoin
+)
+
+// JoinOptions configures Join. On names the key columns, which must exist
+// in both tables. Suffixes are appended to the other non-key columns whose
+// names appear in both tables, "_x" and "_y" when left empty.
+type JoinOptions struct {
+	On       []string
+	How      JoinType
+	Suffixes [2]string
+}
+
+// Join combines the rows of d and other whose key columns are equal, using
+// a hash join on other. String and Categorical keys compare by label, and
+// missing keys never match. The result holds the key columns, then the
+// other columns of d, then those of other. Rows keep the order of d, with
+// the unmatched rows of other last for right and outer joins; cells
+// without a matching row are missing.
+func (d *DataTable) Join(other *DataTable, opts JoinOptions) (*DataTable, error) {
+	if len(opts.On) == 0 {
+		return nil, errors.New("join requires at least one key column")
+	}
+
+	if opts.How < InnerJoin || opts.How > OuterJoin {
+		return nil, fmt.Errorf("unknown join type %d", opts.How)
+	}
+
+	suffixes := opts.Suffixes
+	if suffixes == [2]string{} {
+		suffixes = [2]string{"_x", "_y"}
+	}
+
+	lKeys := make([]int, len(opts.On))
+	rKeys := make([]int, len(opts.On))
+
+	for i, name := range opts.On {
+		l, err := d.findColIdx(name)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to join: %w", err)
+		}
+
+		r, err := other.findColIdx(name)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to join: %w", err)
+		}
+
+		if d.col(l).hasLevels() != other.col(r).hasLevels() {
+			return nil, fmt.Errorf("failed to join: key %s has type %s and %s",
+				name, d.col(l).typ, other.col(r).typ)
+		}
+
+		lKeys[i], rKeys[i] = l, r
+	}
+
+	lRest := nonKeyCols(d, lKeys)
+	rRest := nonKeyCols(other, rKeys)
+
+	result := d.emptyLike(append(append([]int(nil), lKeys...), lRest...))
+
+	for _, colIdx := range rRest {
+		result.Cols = append(result.Cols, other.Cols[colIdx])
+		result.columns = append(result.columns, other.col(colIdx).clone())
+	}
+
+	result.Matrix.Cols = len(result.Cols)
+
+	// Suffix the non-key columns whose names clash across the two tables
+	nKeys, nLeft := len(lKeys), len(lRest)
+
+	for i := nKeys; i < nKeys+nLeft; i++ {
+		for j := nKeys + nLeft; j < len(result.Cols); j++ {
+			if strings.EqualFold(result.Cols[i], result.Cols[j]) {
+				result.Cols[i] = d.Cols[lRest[i-nKeys]] + suffixes[0]
+				result.Cols[j] = other.Cols[rRest[j-nKeys-nLeft]] + suffixes[1]
+			}
+		}
+	}
+
+	index := make(map[string][]int)
+
+	for r := range other.Matrix.Rows {
+		key, ok := other.joinKey(r, rKeys)
+
+		if ok {
+			index[key] = append(index[key], r)
+		}
+	}
+
+	matched := make([]bool, other.Matrix.Rows)
+	row := make([]float64, len(result.Cols))
+	lm, rm := d.Matrix, other.Matrix
+
+	emit := func(l, r int) error {
+		for i := range row {
+			row[i] = NA()
+		}
+
+		if l >= 0 {
+			for i, colIdx := range lKeys {
+				row[i] = lm.Data[l*lm.Cols+colIdx]
+			}
+
+			for i, colIdx := range lRest {
+				row[nKeys+i] = lm.Data[l*lm.Cols+colIdx]
+			}
+		} else {
+			for i, colIdx := range rKeys {
+				v, err := other.col(colIdx).recode(rm.Data[r*rm.Cols+colIdx], result.columns[i])
+
+				if err != nil {
+					return err
+				}
+
+				row[i] = v
+			}
+		}
+
+		if r >= 0 {
+			for i, colIdx := range rRest {
+				row[nKeys+nLeft+i] = rm.Data[r*rm.Cols+colIdx]
+			}
+		}
+
+		return result.AddRow(row)
+	}
+
+	for l := range lm.Rows {
+		var rows []int
+
+		if key, ok := d.joinKey(l, lKeys); ok {
+			rows = index[key]
+		}
+
+		for _, r := range rows {
+			matched[r] = true
+
+			err := emit(l, r)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to join: %w", err)
+			}
+		}
+
+		if len(rows) == 0 && (opts.How == LeftJoin || opts.How == OuterJoin) {
+			err := emit(l, -1)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to join: %w", err)
+			}
+		}
+	}
+
+	if opts.How == RightJoin || opts.How == OuterJoin {
+		for r := range rm.Rows {
+			if matched[r] {
+				continue
+			}
+
+			err := emit(-1, r)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to join: %w", err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// joinKey encodes the key columns of a row for hashing, reporting false
+// when any of them is missing.
+func (d *DataTable) joinKey(row int, keys []int) (string, bool) {
+	m := d.Matrix
+	var b []byte
+
+	for _, colIdx := range keys {
+		v := m.Data[row*m.Cols+colIdx]
+
+		if math.IsNaN(v) {
+			return "", false
+		}
+
+		c := d.col(colIdx)
+
+		if c.hasLevels() {
+			s, err := c.level(v)
+
+			if err != nil {
+				return "", false
+			}
+
+			b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+			b = append(b, s...)
+
+			continue
+		}
+
+		// Adding 0 folds -0 into 0 so the two compare equal
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v+0))
+	}
+
+	return string(b), true
+}
+
+func nonKeyCols(d *DataTable, keys []int) []int {
+	rest := make([]int, 0, len(d.Cols))
+
+	for i := range d.Cols {
+		isKey := false
+
+		for _, k := range keys {
+			isKey = isKey || k == i
+		}
+
+		if !isKey {
+			rest = append(rest, i)
+		}
+	}
+
+	return rest
+}
@@ -0,0 +1,107 @@
+package datatable
+
+import (
+	"math"
+	"testing"
+)
+
+// wineSchema and wineRows hold wines by id, one with a missing id, and
+// metaSchema and metaRows a table to join them with whose color codes
+// differ from the wines.
+var wineSchema = Schema{
+	{Name: "id", Type: Int},
+	{Name: "color", Type: Categorical},
+	{Name: "score", Type: Float},
+}
+
+var wineRows = [][]any{{1, "red", 5.0}, {2, "white", 6.0}, {3, "red", 7.0}, {nil, "red", 1.0}}
+
+var metaSchema = Schema{
+	{Name: "color", Type: Categorical},
+	{Name: "id", Type: Int},
+	{Name: "score", Type: Float},
+}
+
+var metaRows = [][]any{{"white", 2, 0.6}, {"red", 1, 0.5}, {"red", 1, 0.4}, {"rose", 4, 0.9}}
+
+func TestJoin(t *testing.T) {
+	wines := newTable(t, wineSchema, wineRows)
+	meta := newTable(t, metaSchema, metaRows)
+
+	tests := []struct {
+		how    JoinType
+		ids    []float64
+		scores []float64
+	}{
+		{InnerJoin, []float64{1, 1, 2}, []float64{0.5, 0.4, 0.6}},
+		{LeftJoin, []float64{1, 1, 2, 3, math.NaN()}, []float64{0.5, 0.4, 0.6, math.NaN(), math.NaN()}},
+		{RightJoin, []float64{1, 1, 2, 4}, []float64{0.5, 0.4, 0.6, 0.9}},
+		{OuterJoin, []float64{1, 1, 2, 3, math.NaN(), 4}, []float64{0.5, 0.4, 0.6, math.NaN(), math.NaN(), 0.9}},
+	}
+
+	for _, tt := range tests {
+		out, err := wines.Join(meta, JoinOptions{On: []string{"id", "color"}, How: tt.how})
+
+		if err != nil {
+			t.Fatalf("expected no error, got error: %v", err)
+		}
+
+		exp := []string{"id", "color", "score_x", "score_y"}
+
+		for i, c := range exp {
+			if out.Cols[i] != c {
+				t.Fatalf("expected columns %v, got %v", exp, out.Cols)
+			}
+		}
+
+		if out.Matrix.Rows != len(tt.ids) {
+			t.Fatalf("expected %d rows for join %d, got %d", len(tt.ids), tt.how, out.Matrix.Rows)
+		}
+
+		for row := range tt.ids {
+			id, _ := out.FloatAt(row, "id")
+			score, _ := out.FloatAt(row, "score_y")
+
+			if !sameFloat(id, tt.ids[row]) || !sameFloat(score, tt.scores[row]) {
+				t.Errorf("expected id %v score %v in row %d of join %d, got %v %v",
+					tt.ids[row], tt.scores[row], row, tt.how, id, score)
+			}
+		}
+	}
+
+	out, _ := wines.Join(meta, JoinOptions{On: []string{"id", "color"}, How: OuterJoin})
+	color, err := out.StringAt(5, "color")
+
+	if err != nil || color != "rose" {
+		t.Errorf("expected right key %v, got %v (%v)", "rose", color, err)
+	}
+}
+
+func TestJoinSuffixes(t *testing.T) {
+	wines := newTable(t, wineSchema, wineRows)
+	meta := newTable(t, metaSchema, metaRows)
+
+	out, err := wines.Join(meta, JoinOptions{On: []string{"id"}, Suffixes: [2]string{"_wine", "_meta"}})
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	exp := []string{"id", "color_wine", "score_wine", "color_meta", "score_meta"}
+
+	for i, c := range exp {
+		if out.Cols[i] != c {
+			t.Fatalf("expected columns %v, got %v", exp, out.Cols)
+		}
+	}
+
+	_, err = wines.Join(meta, JoinOptions{On: []string{"missing"}})
+
+	if err == nil {
+		t.Errorf("expected error for missing key column, got nil")
+	}
+}
+
+func sameFloat(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
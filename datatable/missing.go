@@ -0,0 +1,117 @@
+package datatable
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Missing values are stored as NaN in every column type. AddRecord stores
+// nil as missing, typed getters return ErrMissing for them, and FloatAt
+// returns the NaN itself.
+var ErrMissing = errors.New("missing value")
+
+// NA returns the value used to mark a missing cell.
+func NA() float64 {
+	return math.NaN()
+}
+
+func IsMissing(v float64) bool {
+	return math.IsNaN(v)
+}
+
+// MissingCount returns the number of missing cells in a column.
+func (d *DataTable) MissingCount(name string) (int, error) {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return d.missingCount(colIdx), nil
+}
+
+// MissingCounts returns the number of missing cells in every column.
+func (d *DataTable) MissingCounts() map[string]int {
+	counts := make(map[string]int, len(d.Cols))
+
+	for i, name := range d.Cols {
+		counts[name] = d.missingCount(i)
+	}
+
+	return counts
+}
+
+func (d *DataTable) missingCount(colIdx int) int {
+	m := d.Matrix
+	n := 0
+
+	for row := range m.Rows {
+		if math.IsNaN(m.Data[row*m.Cols+colIdx]) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// DropMissing returns a new table without the rows that are missing a
+// value in any of the named columns, or in any column when none are given.
+func (d *DataTable) DropMissing(cols ...string) (*DataTable, error) {
+	idxs := rowSpan(0, len(d.Cols))
+
+	if len(cols) > 0 {
+		idxs = make([]int, len(cols))
+
+		for i, name := range cols {
+			colIdx, err := d.findColIdx(name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			idxs[i] = colIdx
+		}
+	}
+
+	m := d.Matrix
+	rows := make([]int, 0, m.Rows)
+
+	for row := range m.Rows {
+		complete := true
+
+		for _, colIdx := range idxs {
+			complete = complete && !math.IsNaN(m.Data[row*m.Cols+colIdx])
+		}
+
+		if complete {
+			rows = append(rows, row)
+		}
+	}
+
+	return d.take(rows), nil
+}
+
+// SetAt overwrites one cell with a Go value, converted as in AddRecord.
+// Passing nil marks the cell missing.
+func (d *DataTable) SetAt(row int, name string, v any) error {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return err
+	}
+
+	s, err := d.col(colIdx).encode(v)
+
+	if err != nil {
+		return fmt.Errorf("failed to set value of column %s: %w", name, err)
+	}
+
+	err = d.Matrix.Set(row, colIdx, s)
+
+	if err != nil {
+		return fmt.Errorf("failed to set value of column %s: %w", name, err)
+	}
+
+	return nil
+}
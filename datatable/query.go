@@ -0,0 +1,321 @@
+package datatable
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Record is a single row of a DataTable addressed by column name.
+type Record struct {
+	Cols    []string
+	Values  []float64
+	columns []*column
+}
+
+func (r Record) index(name string) (int, error) {
+	return findCol(r.Cols, name)
+}
+
+// Value returns the stored numeric value of a column.
+func (r Record) Value(name string) (float64, error) {
+	i, err := r.index(name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Values[i], nil
+}
+
+// Label returns the text of a String or Categorical column.
+func (r Record) Label(name string) (string, error) {
+	i, err := r.index(name)
+
+	if err != nil {
+		return "", err
+	}
+
+	c := columnAt(r.columns, i)
+
+	if !c.hasLevels() {
+		return "", fmt.Errorf("column %s has type %s", name, c.typ)
+	}
+
+	return c.level(r.Values[i])
+}
+
+func (r Record) Bool(name string) (bool, error) {
+	i, err := r.index(name)
+
+	if err != nil {
+		return false, err
+	}
+
+	c := columnAt(r.columns, i)
+
+	if c.typ != Bool {
+		return false, fmt.Errorf("column %s has type %s", name, c.typ)
+	}
+
+	if math.IsNaN(r.Values[i]) {
+		return false, ErrMissing
+	}
+
+	return r.Values[i] != 0, nil
+}
+
+func (r Record) Time(name string) (time.Time, error) {
+	i, err := r.index(name)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	c := columnAt(r.columns, i)
+
+	if c.typ != Timestamp {
+		return time.Time{}, fmt.Errorf("column %s has type %s", name, c.typ)
+	}
+
+	if math.IsNaN(r.Values[i]) {
+		return time.Time{}, ErrMissing
+	}
+
+	return timeFromUnix(r.Values[i]), nil
+}
+
+// Row returns row i as a Record. Its Values are a copy.
+func (d *DataTable) Row(i int) (Record, error) {
+	v, err := d.Matrix.SliceRow(i)
+
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to get datatable row: %w", err)
+	}
+
+	return Record{
+		Cols:    d.Cols,
+		Values:  v,
+		columns: d.columns,
+	}, nil
+}
+
+// Select returns a new table with only the named columns, in that order.
+func (d *DataTable) Select(cols ...string) (*DataTable, error) {
+	idxs := make([]int, len(cols))
+
+	for i, name := range cols {
+		colIdx, err := d.findColIdx(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		idxs[i] = colIdx
+	}
+
+	result := d.emptyLike(idxs)
+	m := result.Matrix
+	m.Rows = d.Matrix.Rows
+	m.Data = make([]float64, m.Rows*m.Cols)
+
+	for row := range d.Matrix.Rows {
+		src := d.Matrix.Data[row*d.Matrix.Cols : (row+1)*d.Matrix.Cols]
+		dst := m.Data[row*m.Cols : (row+1)*m.Cols]
+
+		for i, colIdx := range idxs {
+			dst[i] = src[colIdx]
+		}
+	}
+
+	return result, nil
+}
+
+// Filter returns a new table with the rows for which pred returns true.
+// The Values of the Record passed to pred are a copy of the row, so writes
+// to them do not change d. The copy is reused for the next row; pred must
+// copy it to keep it.
+func (d *DataTable) Filter(pred func(r Record) bool) *DataTable {
+	rows := make([]int, 0, d.Matrix.Rows)
+	r := Record{
+		Cols:    d.Cols,
+		Values:  make([]float64, d.Matrix.Cols),
+		columns: d.columns,
+	}
+
+	for row := range d.Matrix.Rows {
+		copy(r.Values, d.Matrix.Data[row*d.Matrix.Cols:(row+1)*d.Matrix.Cols])
+
+		if pred(r) {
+			rows = append(rows, row)
+		}
+	}
+
+	return d.take(rows)
+}
+
+// SortBy returns a new table stably sorted on one column. String and
+// Categorical columns sort by label; NaN values sort last.
+func (d *DataTable) SortBy(name string, asc bool) (*DataTable, error) {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := d.col(colIdx)
+	m := d.Matrix
+	rows := rowSpan(0, m.Rows)
+
+	less := func(a, b float64) bool {
+		return a < b
+	}
+
+	if c.hasLevels() {
+		less = func(a, b float64) bool {
+			la, _ := c.level(a)
+			lb, _ := c.level(b)
+
+			return la < lb
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a := m.Data[rows[i]*m.Cols+colIdx]
+		b := m.Data[rows[j]*m.Cols+colIdx]
+
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return !math.IsNaN(a) && math.IsNaN(b)
+		}
+
+		if asc {
+			return less(a, b)
+		}
+
+		return less(b, a)
+	})
+
+	return d.take(rows), nil
+}
+
+// Head returns a new table with the first n rows.
+func (d *DataTable) Head(n int) *DataTable {
+	n = max(0, min(n, d.Matrix.Rows))
+
+	return d.take(rowSpan(0, n))
+}
+
+// Tail returns a new table with the last n rows.
+func (d *DataTable) Tail(n int) *DataTable {
+	n = max(0, min(n, d.Matrix.Rows))
+
+	return d.take(rowSpan(d.Matrix.Rows-n, d.Matrix.Rows))
+}
+
+// Slice returns a new table with the given rows, in the given order.
+func (d *DataTable) Slice(rows []int) (*DataTable, error) {
+	for _, row := range rows {
+		if row < 0 || row >= d.Matrix.Rows {
+			return nil, fmt.Errorf("row index %d out of bounds for datatable with %d rows",
+				row, d.Matrix.Rows)
+		}
+	}
+
+	return d.take(rows), nil
+}
+
+// Concat stacks the rows of other tables under d. Every table must have
+// the same column names and types; String and Categorical levels are
+// merged.
+func (d *DataTable) Concat(others ...*DataTable) (*DataTable, error) {
+	result := d.take(rowSpan(0, d.Matrix.Rows))
+
+	for _, o := range others {
+		if len(o.Cols) != len(d.Cols) {
+			return nil, fmt.Errorf("cannot concat datatable with %d columns onto %d columns",
+				len(o.Cols), len(d.Cols))
+		}
+
+		idxs := make([]int, len(d.Cols))
+
+		for i, name := range d.Cols {
+			colIdx, err := o.findColIdx(name)
+
+			if err != nil {
+				return nil, fmt.Errorf("cannot concat: %w", err)
+			}
+
+			if o.col(colIdx).typ != d.col(i).typ {
+				return nil, fmt.Errorf("cannot concat: column %s has type %s and %s",
+					name, d.col(i).typ, o.col(colIdx).typ)
+			}
+
+			idxs[i] = colIdx
+		}
+
+		row := make([]float64, len(d.Cols))
+
+		for r := range o.Matrix.Rows {
+			src := o.Matrix.Data[r*o.Matrix.Cols : (r+1)*o.Matrix.Cols]
+
+			for i, colIdx := range idxs {
+				v, err := o.col(colIdx).recode(src[colIdx], result.columns[i])
+
+				if err != nil {
+					return nil, fmt.Errorf("cannot concat column %s: %w", d.Cols[i], err)
+				}
+
+				row[i] = v
+			}
+
+			err := result.AddRow(row)
+
+			if err != nil {
+				return nil, fmt.Errorf("cannot concat: %w", err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// take copies the given rows into a new table with the same schema.
+func (d *DataTable) take(rows []int) *DataTable {
+	result := d.emptyLike(rowSpan(0, len(d.Cols)))
+	m := result.Matrix
+	m.Rows = len(rows)
+	m.Data = make([]float64, len(rows)*m.Cols)
+
+	for i, row := range rows {
+		copy(m.Data[i*m.Cols:(i+1)*m.Cols], d.Matrix.Data[row*d.Matrix.Cols:(row+1)*d.Matrix.Cols])
+	}
+
+	return result
+}
+
+// emptyLike returns a table with no rows and copies of the given columns.
+func (d *DataTable) emptyLike(colIdxs []int) *DataTable {
+	cols := make([]string, len(colIdxs))
+	columns := make([]*column, len(colIdxs))
+
+	for i, colIdx := range colIdxs {
+		cols[i] = d.Cols[colIdx]
+		columns[i] = d.col(colIdx).clone()
+	}
+
+	result := NewDataTable(cols)
+	result.columns = columns
+
+	return result
+}
+
+func rowSpan(start, end int) []int {
+	rows := make([]int, end-start)
+
+	for i := range rows {
+		rows[i] = start + i
+	}
+
+	return rows
+}
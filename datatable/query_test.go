@@ -0,0 +1,244 @@
+package datatable
+
+import (
+	"testing"
+)
+
+func TestRow(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	r, err := d.Row(1)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	v, err := r.Value("count")
+
+	if err != nil || v != 7 {
+		t.Errorf("expected count %v, got %v (%v)", 7, v, err)
+	}
+
+	s, err := r.Label("Variety")
+
+	if err != nil || s != "Virginica" {
+		t.Errorf("expected variety %v, got %v (%v)", "Virginica", s, err)
+	}
+
+	_, err = r.Label("count")
+
+	if err == nil {
+		t.Errorf("expected error for label of int column, got nil")
+	}
+
+	_, err = d.Row(3)
+
+	if err == nil {
+		t.Errorf("expected error for out of bounds row, got nil")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	s, err := d.Select("variety", "length")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if !(s.Matrix.Rows == 3 && s.Matrix.Cols == 2) {
+		t.Fatalf("expected dimensions %dx%d, got %dx%d", 3, 2, s.Matrix.Rows, s.Matrix.Cols)
+	}
+
+	label, err := s.StringAt(1, "variety")
+
+	if err != nil || label != "Virginica" {
+		t.Errorf("expected variety %v, got %v (%v)", "Virginica", label, err)
+	}
+
+	v, _ := s.FloatAt(2, "length")
+
+	if v != 4.7 {
+		t.Errorf("expected length %v, got %v", 4.7, v)
+	}
+
+	_, err = d.Select("missing")
+
+	if err == nil {
+		t.Errorf("expected error for missing column, got nil")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	f := d.Filter(func(r Record) bool {
+		s, _ := r.Label("variety")
+
+		return s == "Setosa"
+	})
+
+	if f.Matrix.Rows != 2 {
+		t.Fatalf("expected %d rows, got %d", 2, f.Matrix.Rows)
+	}
+
+	note, _ := f.StringAt(1, "note")
+
+	if note != "third" {
+		t.Errorf("expected note %v, got %v", "third", note)
+	}
+}
+
+func TestFilterCopiesRow(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	_ = d.Filter(func(r Record) bool {
+		r.Values[0] = -1
+
+		return true
+	})
+
+	if v, _ := d.FloatAt(0, "length"); v != 5.1 {
+		t.Errorf("expected length %v, got %v", 5.1, v)
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	s, err := d.SortBy("length", true)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	for i, exp := range []string{"third", "second", "first"} {
+		note, _ := s.StringAt(i, "note")
+
+		if note != exp {
+			t.Errorf("expected note %v at row %d, got %v", exp, i, note)
+		}
+	}
+
+	// Stable and sorted by label, not level code
+	s, err = d.SortBy("variety", false)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	for i, exp := range []string{"second", "first", "third"} {
+		note, _ := s.StringAt(i, "note")
+
+		if note != exp {
+			t.Errorf("expected note %v at row %d, got %v", exp, i, note)
+		}
+	}
+}
+
+func TestHeadTailSlice(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	h := d.Head(2)
+	tl := d.Tail(10)
+
+	if h.Matrix.Rows != 2 || tl.Matrix.Rows != 3 {
+		t.Fatalf("expected %d and %d rows, got %d and %d", 2, 3, h.Matrix.Rows, tl.Matrix.Rows)
+	}
+
+	note, _ := d.Tail(1).StringAt(0, "note")
+
+	if note != "third" {
+		t.Errorf("expected note %v, got %v", "third", note)
+	}
+
+	s, err := d.Slice([]int{2, 0})
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	note, _ = s.StringAt(0, "note")
+
+	if note != "third" {
+		t.Errorf("expected note %v, got %v", "third", note)
+	}
+
+	_, err = d.Slice([]int{3})
+
+	if err == nil {
+		t.Errorf("expected error for out of bounds row, got nil")
+	}
+
+	// Copies do not share data with the source table
+	h.Matrix.Data[0] = 100
+
+	if d.Matrix.Data[0] == 100 {
+		t.Errorf("expected head to copy datatable data")
+	}
+}
+
+func TestConcat(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	o := NewDataTableWithSchema(Schema{
+		{Name: "variety", Type: Categorical},
+		{Name: "length", Type: Float},
+	})
+
+	err := o.AddRecord("Versicolor", 6.0)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	err = o.AddRecord("Virginica", 6.3)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	s, err := d.Select("length", "variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	c, err := s.Concat(o)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if c.Matrix.Rows != 5 {
+		t.Fatalf("expected %d rows, got %d", 5, c.Matrix.Rows)
+	}
+
+	for i, exp := range []string{"Setosa", "Virginica", "Setosa", "Versicolor", "Virginica"} {
+		label, _ := c.StringAt(i, "variety")
+
+		if label != exp {
+			t.Errorf("expected variety %v at row %d, got %v", exp, i, label)
+		}
+	}
+
+	levels, _ := c.Levels("variety")
+
+	if len(levels) != 3 {
+		t.Errorf("expected %d levels, got %v", 3, levels)
+	}
+
+	// The source table's levels are unchanged
+	levels, _ = s.Levels("variety")
+
+	if len(levels) != 2 {
+		t.Errorf("expected %d levels, got %v", 2, levels)
+	}
+
+	_, err = d.Concat(o)
+
+	if err == nil {
+		t.Errorf("expected error for mismatched columns, got nil")
+	}
+}
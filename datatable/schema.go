@@ -0,0 +1,595 @@
+package datatable
+
+import (
+	"fmt"
+	"gonn/matrix"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType describes how the float64 cells of a column are interpreted.
+// Every column is stored numerically: Int and Bool as their value, String
+// and Categorical as an index into the column's levels, and Timestamp as
+// Unix seconds.
+type ColumnType int
+
+const (
+	Float ColumnType = iota
+	Int
+	Bool
+	String
+	Categorical
+	Timestamp
+)
+
+func (t ColumnType) String() string {
+	switch t {
+	case Float:
+		return "float"
+	case Int:
+		return "int"
+	case Bool:
+		return "bool"
+	case String:
+		return "string"
+	case Categorical:
+		return "categorical"
+	case Timestamp:
+		return "timestamp"
+	default:
+		return fmt.Sprintf("ColumnType(%d)", int(t))
+	}
+}
+
+// Field is one named, typed column of a Schema.
+type Field struct {
+	Name string
+	Type ColumnType
+}
+
+type Schema []Field
+
+// column holds the type of a column and, for String and Categorical
+// columns, the dictionary of levels its codes refer to.
+type column struct {
+	typ    ColumnType
+	levels []string
+	index  map[string]int
+}
+
+func newColumn(typ ColumnType) *column {
+	c := column{typ: typ}
+
+	if c.hasLevels() {
+		c.index = make(map[string]int)
+	}
+
+	return &c
+}
+
+func (c *column) hasLevels() bool {
+	return c.typ == String || c.typ == Categorical
+}
+
+func (c *column) clone() *column {
+	cp := newColumn(c.typ)
+
+	for _, s := range c.levels {
+		cp.code(s)
+	}
+
+	return cp
+}
+
+func (c *column) code(s string) float64 {
+	idx, ok := c.index[s]
+
+	if !ok {
+		idx = len(c.levels)
+		c.levels = append(c.levels, s)
+		c.index[s] = idx
+	}
+
+	return float64(idx)
+}
+
+func (c *column) level(v float64) (string, error) {
+	if math.IsNaN(v) {
+		return "", ErrMissing
+	}
+
+	idx := int(v)
+
+	if float64(idx) != v || idx < 0 || idx >= len(c.levels) {
+		return "", fmt.Errorf("invalid level code %v", v)
+	}
+
+	return c.levels[idx], nil
+}
+
+// recode converts a stored value of c to the matching value of dst,
+// translating level codes by label.
+func (c *column) recode(v float64, dst *column) (float64, error) {
+	if !c.hasLevels() || math.IsNaN(v) {
+		return v, nil
+	}
+
+	s, err := c.level(v)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return dst.code(s), nil
+}
+
+// encode converts a Go value to the stored float64 for this column type.
+func (c *column) encode(v any) (float64, error) {
+	if v == nil {
+		return NA(), nil
+	}
+
+	if f, ok := v.(float64); ok && math.IsNaN(f) {
+		return f, nil
+	}
+
+	if s, ok := v.(string); ok && !c.hasLevels() {
+		return c.parse(s)
+	}
+
+	switch c.typ {
+	case Float:
+		switch x := v.(type) {
+		case float64:
+			return x, nil
+		case float32:
+			return float64(x), nil
+		case int:
+			return float64(x), nil
+		case int64:
+			return float64(x), nil
+		}
+	case Int:
+		switch x := v.(type) {
+		case int:
+			return float64(x), nil
+		case int64:
+			return float64(x), nil
+		case float64:
+			if x != math.Trunc(x) {
+				return 0, fmt.Errorf("value %v is not an integer", x)
+			}
+
+			return x, nil
+		}
+	case Bool:
+		switch x := v.(type) {
+		case bool:
+			if x {
+				return 1, nil
+			}
+
+			return 0, nil
+		case float64:
+			if x != 0 && x != 1 {
+				return 0, fmt.Errorf("value %v is not a bool", x)
+			}
+
+			return x, nil
+		}
+	case String, Categorical:
+		switch x := v.(type) {
+		case string:
+			return c.code(x), nil
+		case float64:
+			_, err := c.level(x)
+
+			if err != nil {
+				return 0, err
+			}
+
+			return x, nil
+		}
+	case Timestamp:
+		switch x := v.(type) {
+		case time.Time:
+			return float64(x.UnixNano()) / 1e9, nil
+		case float64:
+			return x, nil
+		case int64:
+			return float64(x), nil
+		}
+	}
+
+	return 0, fmt.Errorf("cannot store %T in %s column", v, c.typ)
+}
+
+// parse converts a text cell to the stored float64 for this column type.
+func (c *column) parse(s string) (float64, error) {
+	switch c.typ {
+	case Float:
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	case Int:
+		v, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+
+		return float64(v), err
+	case Bool:
+		v, err := strconv.ParseBool(strings.TrimSpace(s))
+
+		if err != nil || !v {
+			return 0, err
+		}
+
+		return 1, nil
+	case String, Categorical:
+		return c.code(s), nil
+	case Timestamp:
+		t, err := ParseTime(strings.TrimSpace(s))
+
+		if err != nil {
+			return 0, err
+		}
+
+		return c.encode(t)
+	default:
+		return 0, fmt.Errorf("unknown column type %v", c.typ)
+	}
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.DateOnly,
+}
+
+// ParseTime parses the timestamp layouts accepted by Timestamp columns:
+// RFC 3339, "2006-01-02 15:04:05" and a bare date.
+func ParseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		t, err := time.Parse(layout, s)
+
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse %q as a timestamp", s)
+}
+
+// format renders a stored value for display.
+func (c *column) format(v float64) string {
+	if math.IsNaN(v) {
+		return "NA"
+	}
+
+	switch c.typ {
+	case Int:
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	case Bool:
+		return strconv.FormatBool(v != 0)
+	case String, Categorical:
+		s, err := c.level(v)
+
+		if err != nil {
+			return "?"
+		}
+
+		return s
+	case Timestamp:
+		return timeFromUnix(v).Format(time.RFC3339)
+	default:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+}
+
+// text renders a present value in full, as it would be read back.
+func (c *column) text(v float64) string {
+	switch c.typ {
+	case Float:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case Timestamp:
+		return timeFromUnix(v).Format(time.RFC3339Nano)
+	default:
+		return c.format(v)
+	}
+}
+
+func timeFromUnix(v float64) time.Time {
+	sec, frac := math.Modf(v)
+
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC()
+}
+
+func NewDataTableWithSchema(schema Schema) *DataTable {
+	cols := make([]string, len(schema))
+
+	for i, f := range schema {
+		cols[i] = f.Name
+	}
+
+	d := NewDataTable(cols)
+
+	for i, f := range schema {
+		d.columns[i] = newColumn(f.Type)
+	}
+
+	return d
+}
+
+func (d *DataTable) Schema() Schema {
+	schema := make(Schema, len(d.Cols))
+
+	for i, name := range d.Cols {
+		schema[i] = Field{
+			Name: name,
+			Type: d.col(i).typ,
+		}
+	}
+
+	return schema
+}
+
+func (d *DataTable) ColumnType(name string) (ColumnType, error) {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return d.col(colIdx).typ, nil
+}
+
+// Levels returns the distinct values of a String or Categorical column in
+// code order.
+func (d *DataTable) Levels(name string) ([]string, error) {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := d.col(colIdx)
+
+	if !c.hasLevels() {
+		return nil, fmt.Errorf("column %s of type %s has no levels", name, c.typ)
+	}
+
+	levels := make([]string, len(c.levels))
+	copy(levels, c.levels)
+
+	return levels, nil
+}
+
+// AddRecord appends a row of Go values, one per column, converting each to
+// the column's type. Strings are parsed for numeric, bool and timestamp
+// columns and become levels for String and Categorical columns.
+func (d *DataTable) AddRecord(values ...any) error {
+	if len(d.Cols) != len(values) {
+		return fmt.Errorf("mismatch between record length and datatable column length")
+	}
+
+	row := make([]float64, len(values))
+
+	for i, v := range values {
+		s, err := d.col(i).encode(v)
+
+		if err != nil {
+			return fmt.Errorf("failed to add value to column %s: %w", d.Cols[i], err)
+		}
+
+		row[i] = s
+	}
+
+	return d.AddRow(row)
+}
+
+// AddTypedColumn appends a column of the given type built from Go values,
+// converted as in AddRecord.
+func (d *DataTable) AddTypedColumn(f Field, values []any) error {
+	c := newColumn(f.Type)
+	data := make([]float64, len(values))
+
+	for i, v := range values {
+		s, err := c.encode(v)
+
+		if err != nil {
+			return fmt.Errorf("failed to add value to column %s: %w", f.Name, err)
+		}
+
+		data[i] = s
+	}
+
+	err := d.AddColumn(f.Name, data)
+
+	if err != nil {
+		return err
+	}
+
+	d.columns[len(d.columns)-1] = c
+
+	return nil
+}
+
+// ConvertColumn changes the type of a column in place. Int converts to
+// Float, and any type converts to String or Categorical with the text of
+// each cell as its level; missing cells stay missing.
+func (d *DataTable) ConvertColumn(name string, typ ColumnType) error {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return err
+	}
+
+	src, dst := d.col(colIdx), newColumn(typ)
+
+	switch {
+	case src.typ == typ:
+		return nil
+	case dst.hasLevels():
+		for row := range d.Matrix.Rows {
+			i := row*d.Matrix.Cols + colIdx
+
+			if !math.IsNaN(d.Matrix.Data[i]) {
+				d.Matrix.Data[i] = dst.code(src.text(d.Matrix.Data[i]))
+			}
+		}
+	case src.typ != Int || typ != Float:
+		return fmt.Errorf("cannot convert column %s from %s to %s", name, src.typ, typ)
+	}
+
+	d.syncColumns()
+	d.columns[colIdx] = dst
+
+	return nil
+}
+
+func (d *DataTable) cell(row int, name string, types ...ColumnType) (float64, *column, error) {
+	colIdx, err := d.findColIdx(name)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	c := d.col(colIdx)
+
+	typeOK := false
+	for _, t := range types {
+		typeOK = typeOK || c.typ == t
+	}
+
+	if !typeOK {
+		return 0, nil, fmt.Errorf("column %s has type %s", name, c.typ)
+	}
+
+	v, err := d.Matrix.At(row, colIdx)
+
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get cell of column %s: %w", name, err)
+	}
+
+	return v, c, nil
+}
+
+func (d *DataTable) FloatAt(row int, name string) (float64, error) {
+	v, _, err := d.cell(row, name, Float, Int, Bool, Timestamp)
+
+	return v, err
+}
+
+func (d *DataTable) IntAt(row int, name string) (int64, error) {
+	v, _, err := d.cell(row, name, Int, Bool)
+
+	if err == nil && math.IsNaN(v) {
+		err = ErrMissing
+	}
+
+	return int64(v), err
+}
+
+func (d *DataTable) BoolAt(row int, name string) (bool, error) {
+	v, _, err := d.cell(row, name, Bool)
+
+	if err == nil && math.IsNaN(v) {
+		err = ErrMissing
+	}
+
+	return v != 0, err
+}
+
+func (d *DataTable) StringAt(row int, name string) (string, error) {
+	v, c, err := d.cell(row, name, String, Categorical)
+
+	if err != nil {
+		return "", err
+	}
+
+	return c.level(v)
+}
+
+func (d *DataTable) TimeAt(row int, name string) (time.Time, error) {
+	v, _, err := d.cell(row, name, Timestamp)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if math.IsNaN(v) {
+		return time.Time{}, ErrMissing
+	}
+
+	return timeFromUnix(v), nil
+}
+
+// ValueAt returns a cell as a Go value by column index: float64, int64,
+// bool, string or time.Time for the column's type, or nil when missing.
+func (d *DataTable) ValueAt(row, col int) (any, error) {
+	v, err := d.Matrix.At(row, col)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get datatable value: %w", err)
+	}
+
+	if math.IsNaN(v) {
+		return nil, nil
+	}
+
+	c := d.col(col)
+
+	switch c.typ {
+	case Int:
+		return int64(v), nil
+	case Bool:
+		return v != 0, nil
+	case String, Categorical:
+		return c.level(v)
+	case Timestamp:
+		return timeFromUnix(v), nil
+	default:
+		return v, nil
+	}
+}
+
+// ToMatrix returns the named columns (all columns when none are given) as
+// a new numeric matrix for model input. Categorical columns contribute
+// their level codes; free-text String columns must be encoded first.
+func (d *DataTable) ToMatrix(cols ...string) (*matrix.Matrix, error) {
+	if len(cols) == 0 {
+		cols = d.Cols
+	}
+
+	idxs := make([]int, len(cols))
+
+	for i, name := range cols {
+		colIdx, err := d.findColIdx(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if d.col(colIdx).typ == String {
+			return nil, fmt.Errorf("column %s has type string and cannot be used as model input", name)
+		}
+
+		idxs[i] = colIdx
+	}
+
+	m, err := matrix.NewMatrix(d.Matrix.Rows, len(idxs))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create matrix from datatable: %w", err)
+	}
+
+	for row := range d.Matrix.Rows {
+		src := d.Matrix.Data[row*d.Matrix.Cols : (row+1)*d.Matrix.Cols]
+		dst := m.Data[row*m.Cols : (row+1)*m.Cols]
+
+		for i, colIdx := range idxs {
+			dst[i] = src[colIdx]
+		}
+	}
+
+	return m, nil
+}
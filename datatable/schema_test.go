@@ -0,0 +1,283 @@
+package datatable
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// typedSchema and typedRows hold one column of every type.
+var typedSchema = Schema{
+	{Name: "length", Type: Float},
+	{Name: "count", Type: Int},
+	{Name: "ok", Type: Bool},
+	{Name: "note", Type: String},
+	{Name: "variety", Type: Categorical},
+	{Name: "seen", Type: Timestamp},
+}
+
+var typedRows = [][]any{
+	{5.1, 3, true, "first", "Setosa", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	{"4.9", "7", "false", "second", "Virginica", "2024-02-03"},
+	{4.7, int64(1), false, "third", "Setosa", "2024-02-03T10:00:00Z"},
+}
+
+func TestSchema(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	schema := d.Schema()
+
+	if len(schema) != 6 {
+		t.Fatalf("expected %d fields, got %d", 6, len(schema))
+	}
+
+	if schema[4].Name != "variety" || schema[4].Type != Categorical {
+		t.Errorf("expected variety categorical field, got %v %v", schema[4].Name, schema[4].Type)
+	}
+
+	typ, err := d.ColumnType("count")
+
+	if err != nil || typ != Int {
+		t.Errorf("expected %v column type, got %v (%v)", Int, typ, err)
+	}
+
+	levels, err := d.Levels("variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if len(levels) != 2 || levels[0] != "Setosa" || levels[1] != "Virginica" {
+		t.Errorf("expected levels [Setosa Virginica], got %v", levels)
+	}
+}
+
+func TestTypedGetters(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	if v, err := d.FloatAt(1, "length"); err != nil || v != 4.9 {
+		t.Errorf("expected %f, got %f (%v)", 4.9, v, err)
+	}
+
+	if v, err := d.IntAt(1, "count"); err != nil || v != 7 {
+		t.Errorf("expected %d, got %d (%v)", 7, v, err)
+	}
+
+	if v, err := d.BoolAt(0, "ok"); err != nil || !v {
+		t.Errorf("expected %t, got %t (%v)", true, v, err)
+	}
+
+	if v, err := d.StringAt(2, "note"); err != nil || v != "third" {
+		t.Errorf("expected %s, got %s (%v)", "third", v, err)
+	}
+
+	if v, err := d.StringAt(1, "variety"); err != nil || v != "Virginica" {
+		t.Errorf("expected %s, got %s (%v)", "Virginica", v, err)
+	}
+
+	exp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if v, err := d.TimeAt(0, "seen"); err != nil || !v.Equal(exp) {
+		t.Errorf("expected %v, got %v (%v)", exp, v, err)
+	}
+
+	_, err := d.StringAt(0, "length")
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestAddRecordError(t *testing.T) {
+	d := NewDataTableWithSchema(Schema{
+		{Name: "count", Type: Int},
+		{Name: "ok", Type: Bool},
+	})
+
+	if err := d.AddRecord(1.5, true); err == nil {
+		t.Errorf("expected error for non-integer, got nil")
+	}
+
+	if err := d.AddRecord(1, "maybe"); err == nil {
+		t.Errorf("expected error for invalid bool, got nil")
+	}
+
+	if err := d.AddRecord(1); err == nil {
+		t.Errorf("expected error for short record, got nil")
+	}
+
+	if d.Matrix.Rows != 0 {
+		t.Errorf("expected %d rows, got %d", 0, d.Matrix.Rows)
+	}
+}
+
+func TestToMatrix(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	m, err := d.ToMatrix("length", "variety", "ok")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if !(m.Rows == 3 && m.Cols == 3) {
+		t.Fatalf("expected dimensions %dx%d, got %dx%d", 3, 3, m.Rows, m.Cols)
+	}
+
+	if v, _ := m.At(1, 1); v != 1 {
+		t.Errorf("expected variety code %f, got %f", float64(1), v)
+	}
+
+	_, err = d.ToMatrix()
+
+	if err == nil {
+		t.Errorf("expected error for string column, got nil")
+	}
+}
+
+func TestAddTypedColumn(t *testing.T) {
+	d := NewDataTable([]string{"a"})
+
+	_ = d.AddRow([]float64{1})
+	_ = d.AddRow([]float64{2})
+
+	err := d.AddTypedColumn(Field{Name: "label", Type: Categorical}, []any{"x", "y"})
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if v, err := d.StringAt(1, "label"); err != nil || v != "y" {
+		t.Errorf("expected %s, got %s (%v)", "y", v, err)
+	}
+
+	err = d.RemoveColumn("a")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if typ, _ := d.ColumnType("label"); typ != Categorical {
+		t.Errorf("expected %v column type, got %v", Categorical, typ)
+	}
+}
+
+func TestConvertColumn(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	err := d.ConvertColumn("count", Float)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if v, err := d.FloatAt(1, "count"); err != nil || v != 7 {
+		t.Errorf("expected %v, got %v (%v)", 7, v, err)
+	}
+
+	err = d.ConvertColumn("length", Categorical)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if v, err := d.StringAt(1, "length"); err != nil || v != "4.9" {
+		t.Errorf("expected %s, got %s (%v)", "4.9", v, err)
+	}
+
+	err = d.ConvertColumn("ok", Int)
+
+	if err == nil {
+		t.Errorf("expected an error converting bool to int")
+	}
+}
+
+func TestMissing(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	err := d.AddRecord(nil, nil, nil, nil, nil, nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	err = d.SetAt(0, "variety", nil)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	counts := d.MissingCounts()
+
+	if counts["variety"] != 2 || counts["length"] != 1 {
+		t.Errorf("expected 2 missing varieties and 1 missing length, got %v", counts)
+	}
+
+	_, err = d.StringAt(0, "variety")
+
+	if !errors.Is(err, ErrMissing) {
+		t.Errorf("expected %v, got %v", ErrMissing, err)
+	}
+
+	_, err = d.IntAt(3, "count")
+
+	if !errors.Is(err, ErrMissing) {
+		t.Errorf("expected %v, got %v", ErrMissing, err)
+	}
+
+	v, err := d.FloatAt(3, "length")
+
+	if err != nil || !IsMissing(v) {
+		t.Errorf("expected missing length, got %v (%v)", v, err)
+	}
+
+	complete, err := d.DropMissing("variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if complete.Matrix.Rows != 2 {
+		t.Errorf("expected %d rows, got %d", 2, complete.Matrix.Rows)
+	}
+
+	err = d.SetAt(0, "variety", "Versicolor")
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	s, _ := d.StringAt(0, "variety")
+
+	if s != "Versicolor" {
+		t.Errorf("expected %v, got %v", "Versicolor", s)
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	d := newTable(t, typedSchema, typedRows)
+
+	_ = d.AddRecord(nil, nil, nil, nil, nil, nil)
+
+	exp := []any{4.9, int64(7), false, "second", "Virginica", time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)}
+
+	for col, e := range exp {
+		v, err := d.ValueAt(1, col)
+
+		if err != nil || v != e {
+			t.Errorf("expected %v (%T), got %v (%T) (%v)", e, e, v, v, err)
+		}
+
+		v, err = d.ValueAt(3, col)
+
+		if err != nil || v != nil {
+			t.Errorf("expected nil for missing value, got %v (%v)", v, err)
+		}
+	}
+
+	_, err := d.ValueAt(0, 6)
+
+	if err == nil {
+		t.Errorf("expected error for out of bounds column, got nil")
+	}
+}
@@ -0,0 +1,220 @@
+package datatable
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// RandomSplit shuffles the rows with the given seed and divides them into
+// one table per fraction, e.g. RandomSplit(1, 0.7, 0.15, 0.15) for train,
+// validation and test sets. Fractions must be positive and sum to 1.
+func (d *DataTable) RandomSplit(seed uint64, fractions ...float64) ([]*DataTable, error) {
+	bounds, err := splitBounds(d.Matrix.Rows, fractions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows := rowSpan(0, d.Matrix.Rows)
+	r := rand.New(rand.NewPCG(seed, 0))
+	r.Shuffle(len(rows), func(i, j int) {
+		rows[i], rows[j] = rows[j], rows[i]
+	})
+
+	return d.takeParts(rows, bounds), nil
+}
+
+// StratifiedSplit divides the rows like RandomSplit, but splits each
+// distinct value of the label column separately so every table keeps the
+// label proportions of the whole.
+func (d *DataTable) StratifiedSplit(label string, seed uint64, fractions ...float64) ([]*DataTable, error) {
+	colIdx, err := d.findColIdx(label)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = splitBounds(d.Matrix.Rows, fractions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	classes, order := d.groupRows(colIdx)
+	r := rand.New(rand.NewPCG(seed, 0))
+	parts := make([][]int, len(fractions))
+
+	for _, key := range order {
+		rows := classes[key]
+		r.Shuffle(len(rows), func(i, j int) {
+			rows[i], rows[j] = rows[j], rows[i]
+		})
+
+		bounds, _ := splitBounds(len(rows), fractions)
+		start := 0
+
+		for i, end := range bounds {
+			parts[i] = append(parts[i], rows[start:end]...)
+			start = end
+		}
+	}
+
+	result := make([]*DataTable, len(parts))
+
+	for i, rows := range parts {
+		r.Shuffle(len(rows), func(a, b int) {
+			rows[a], rows[b] = rows[b], rows[a]
+		})
+
+		result[i] = d.take(rows)
+	}
+
+	return result, nil
+}
+
+// TimeSplit orders the rows by a column, usually a Timestamp, and divides
+// them so each table only holds rows later than those of the one before.
+// NaN values sort last.
+func (d *DataTable) TimeSplit(col string, fractions ...float64) ([]*DataTable, error) {
+	colIdx, err := d.findColIdx(col)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bounds, err := splitBounds(d.Matrix.Rows, fractions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := d.Matrix
+	rows := rowSpan(0, m.Rows)
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a := m.Data[rows[i]*m.Cols+colIdx]
+		b := m.Data[rows[j]*m.Cols+colIdx]
+
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return !math.IsNaN(a) && math.IsNaN(b)
+		}
+
+		return a < b
+	})
+
+	return d.takeParts(rows, bounds), nil
+}
+
+// GroupSplit shuffles the distinct values of the group column and assigns
+// whole groups to tables, so rows sharing a group never appear in more
+// than one. Table sizes follow the fractions as closely as the group sizes
+// allow.
+func (d *DataTable) GroupSplit(group string, seed uint64, fractions ...float64) ([]*DataTable, error) {
+	colIdx, err := d.findColIdx(group)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bounds, err := splitBounds(d.Matrix.Rows, fractions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	groups, order := d.groupRows(colIdx)
+	r := rand.New(rand.NewPCG(seed, 0))
+	r.Shuffle(len(order), func(i, j int) {
+		order[i], order[j] = order[j], order[i]
+	})
+
+	parts := make([][]int, len(fractions))
+	assigned, part := 0, 0
+
+	for _, key := range order {
+		for part < len(bounds)-1 && assigned >= bounds[part] {
+			part++
+		}
+
+		parts[part] = append(parts[part], groups[key]...)
+		assigned += len(groups[key])
+	}
+
+	result := make([]*DataTable, len(parts))
+
+	for i, rows := range parts {
+		result[i] = d.take(rows)
+	}
+
+	return result, nil
+}
+
+// groupRows returns the row indices for each distinct value of a column,
+// keyed as in GroupBy, and the keys in order of first appearance. All NaN
+// values form one group.
+func (d *DataTable) groupRows(colIdx int) (map[uint64][]int, []uint64) {
+	m := d.Matrix
+	groups := make(map[uint64][]int)
+	order := make([]uint64, 0)
+
+	for row := range m.Rows {
+		key := groupKey(m.Data[row*m.Cols+colIdx])
+
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+
+		groups[key] = append(groups[key], row)
+	}
+
+	return groups, order
+}
+
+func (d *DataTable) takeParts(rows []int, bounds []int) []*DataTable {
+	parts := make([]*DataTable, len(bounds))
+	start := 0
+
+	for i, end := range bounds {
+		parts[i] = d.take(rows[start:end])
+		start = end
+	}
+
+	return parts
+}
+
+// splitBounds returns the exclusive end row of each part when n rows are
+// divided by fractions, rounding the cumulative fraction so the parts
+// always cover every row.
+func splitBounds(n int, fractions []float64) ([]int, error) {
+	if len(fractions) == 0 {
+		return nil, fmt.Errorf("split requires at least one fraction")
+	}
+
+	sum := 0.0
+
+	for _, f := range fractions {
+		if !(f > 0) {
+			return nil, fmt.Errorf("split fraction %v must be positive", f)
+		}
+
+		sum += f
+	}
+
+	if math.Abs(sum-1) > 1e-9 {
+		return nil, fmt.Errorf("split fractions must sum to 1, got %v", sum)
+	}
+
+	bounds := make([]int, len(fractions))
+	cum := 0.0
+
+	for i, f := range fractions {
+		cum += f
+		bounds[i] = int(math.Round(cum * float64(n)))
+	}
+
+	bounds[len(bounds)-1] = n
+
+	return bounds, nil
+}
@@ -0,0 +1,184 @@
+package datatable
+
+import (
+	"math"
+	"testing"
+)
+
+// splitSchema and splitRows hold 20 rows with an id, a label that is 1 for
+// a quarter of the rows, and a group of 2 rows each.
+var splitSchema = Schema{
+	{Name: "id", Type: Float},
+	{Name: "label", Type: Float},
+	{Name: "group", Type: Float},
+}
+
+var splitRows = [][]any{
+	{0, 1, 0},
+	{1, 0, 0},
+	{2, 0, 1},
+	{3, 0, 1},
+	{4, 1, 2},
+	{5, 0, 2},
+	{6, 0, 3},
+	{7, 0, 3},
+	{8, 1, 4},
+	{9, 0, 4},
+	{10, 0, 5},
+	{11, 0, 5},
+	{12, 1, 6},
+	{13, 0, 6},
+	{14, 0, 7},
+	{15, 0, 7},
+	{16, 1, 8},
+	{17, 0, 8},
+	{18, 0, 9},
+	{19, 0, 9},
+}
+
+func ids(d *DataTable) map[float64]bool {
+	set := make(map[float64]bool)
+
+	for row := range d.Matrix.Rows {
+		set[d.Matrix.Data[row*d.Matrix.Cols]] = true
+	}
+
+	return set
+}
+
+func TestRandomSplit(t *testing.T) {
+	d := newTable(t, splitSchema, splitRows)
+
+	parts, err := d.RandomSplit(7, 0.6, 0.2, 0.2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	seen := make(map[float64]bool)
+
+	for i, exp := range []int{12, 4, 4} {
+		if parts[i].Matrix.Rows != exp {
+			t.Errorf("expected %d rows in part %d, got %d", exp, i, parts[i].Matrix.Rows)
+		}
+
+		for id := range ids(parts[i]) {
+			if seen[id] {
+				t.Errorf("expected row %v in only one part", id)
+			}
+
+			seen[id] = true
+		}
+	}
+
+	if len(seen) != 20 {
+		t.Errorf("expected every row to be assigned, got %d", len(seen))
+	}
+
+	again, _ := d.RandomSplit(7, 0.6, 0.2, 0.2)
+
+	for i := range parts {
+		for j, v := range parts[i].Matrix.Data {
+			if again[i].Matrix.Data[j] != v {
+				t.Fatalf("expected the same seed to give the same split")
+			}
+		}
+	}
+
+	_, err = d.RandomSplit(7, 0.6, 0.6)
+
+	if err == nil {
+		t.Errorf("expected error for fractions over 1, got nil")
+	}
+}
+
+func TestStratifiedSplit(t *testing.T) {
+	d := newTable(t, splitSchema, splitRows)
+
+	parts, err := d.StratifiedSplit("label", 3, 0.8, 0.2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	for i, exp := range []float64{4, 1} {
+		positives := 0.0
+
+		for row := range parts[i].Matrix.Rows {
+			positives += parts[i].Matrix.Data[row*3+1]
+		}
+
+		if positives != exp {
+			t.Errorf("expected %v positive labels in part %d, got %v", exp, i, positives)
+		}
+	}
+}
+
+func TestTimeSplit(t *testing.T) {
+	d := newTable(t, splitSchema, splitRows)
+	shuffled, _ := d.Slice([]int{5, 19, 0, 12, 3, 7, 18, 1, 2, 4, 6, 8, 9, 10, 11, 13, 14, 15, 16, 17})
+
+	parts, err := shuffled.TimeSplit("id", 0.75, 0.25)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	for id := range ids(parts[1]) {
+		if id < 15 {
+			t.Errorf("expected only rows after 15 in the last part, got %v", id)
+		}
+	}
+}
+
+func TestGroupSplit(t *testing.T) {
+	d := newTable(t, splitSchema, splitRows)
+
+	parts, err := d.GroupSplit("group", 11, 0.7, 0.3)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	if parts[0].Matrix.Rows != 14 || parts[1].Matrix.Rows != 6 {
+		t.Errorf("expected %d and %d rows, got %d and %d", 14, 6, parts[0].Matrix.Rows, parts[1].Matrix.Rows)
+	}
+
+	groups := make(map[float64]int)
+
+	for i, p := range parts {
+		for row := range p.Matrix.Rows {
+			g := p.Matrix.Data[row*3+2]
+
+			if prev, ok := groups[g]; ok && prev != i {
+				t.Errorf("expected group %v in only one part", g)
+			}
+
+			groups[g] = i
+		}
+	}
+}
+
+func TestGroupSplitMissing(t *testing.T) {
+	d := NewDataTable([]string{"group"})
+	_ = d.AddRow([]float64{math.Inf(-1)})
+	_ = d.AddRow([]float64{math.NaN()})
+	_ = d.AddRow([]float64{math.Inf(-1)})
+	_ = d.AddRow([]float64{NA()})
+
+	parts, err := d.GroupSplit("group", 5, 0.5, 0.5)
+
+	if err != nil {
+		t.Fatalf("expected no error, got error: %v", err)
+	}
+
+	for i, p := range parts {
+		if p.Matrix.Rows != 2 {
+			t.Fatalf("expected %d rows in part %d, got %d", 2, i, p.Matrix.Rows)
+		}
+
+		if math.IsNaN(p.Matrix.Data[0]) != math.IsNaN(p.Matrix.Data[1]) {
+			t.Errorf("expected missing values and -Inf in separate groups, got %v", p.Matrix.Data)
+		}
+	}
+}
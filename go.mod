@@ -1,3 +1,5 @@
 module gonn
 
 go 1.24.2
+
+require github.com/klauspost/compress v1.18.0
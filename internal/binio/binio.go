@@ -0,0 +1,152 @@
+// Package binio holds the little-endian encoder and decoder shared by the
+// model and scaler file formats. Each keeps the first error and skips every
+// later call, so a format is written as a run of calls checked once.
+package binio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// MaxLen guards decoders against allocating from corrupt lengths.
+const MaxLen = 1 << 24
+
+// readChunk is the number of values decoded per read.
+const readChunk = 4096
+
+type Encoder struct {
+	W   io.Writer
+	Err error
+}
+
+func (e *Encoder) Write(v any) {
+	if e.Err != nil {
+		return
+	}
+
+	e.Err = binary.Write(e.W, binary.LittleEndian, v)
+}
+
+func (e *Encoder) String(s string) {
+	e.Write(uint32(len(s)))
+	e.Write([]byte(s))
+}
+
+func (e *Encoder) Strings(ss []string) {
+	e.Write(uint32(len(ss)))
+
+	for _, s := range ss {
+		e.String(s)
+	}
+}
+
+// Floats writes a length-prefixed float slice, narrowed to float32 values
+// when narrow is set.
+func (e *Encoder) Floats(data []float64, narrow bool) {
+	e.Write(uint32(len(data)))
+
+	if !narrow {
+		e.Write(data)
+		return
+	}
+
+	buf := make([]float32, len(data))
+	for i, v := range data {
+		buf[i] = float32(v)
+	}
+
+	e.Write(buf)
+}
+
+type Decoder struct {
+	R   io.Reader
+	Err error
+}
+
+func (d *Decoder) Read(v any) {
+	if d.Err != nil {
+		return
+	}
+
+	d.Err = binary.Read(d.R, binary.LittleEndian, v)
+}
+
+// Length reads a uint32 length of at most MaxLen.
+func (d *Decoder) Length() int {
+	var n uint32
+
+	d.Read(&n)
+
+	if d.Err == nil && n > MaxLen {
+		d.Err = fmt.Errorf("invalid length %d", n)
+	}
+
+	if d.Err != nil {
+		return 0
+	}
+
+	return int(n)
+}
+
+func (d *Decoder) String() string {
+	b := make([]byte, d.Length())
+	d.Read(b)
+
+	return string(b)
+}
+
+func (d *Decoder) Strings() []string {
+	ss := make([]string, d.Length())
+
+	for i := range ss {
+		ss[i] = d.String()
+	}
+
+	return ss
+}
+
+// Floats reads a length-prefixed float slice written by Encoder.Floats,
+// checking the length against the expected count. Values are read in
+// chunks so a corrupt length fails at the end of the stream rather than
+// allocating up front.
+func (d *Decoder) Floats(expected uint64, narrow bool) []float64 {
+	var n uint32
+
+	d.Read(&n)
+
+	if d.Err != nil {
+		return nil
+	}
+
+	if uint64(n) != expected {
+		d.Err = fmt.Errorf("expected %d values, got %d", expected, n)
+		return nil
+	}
+
+	data := make([]float64, 0, min(int(n), readChunk))
+	buf64 := make([]float64, min(int(n), readChunk))
+	buf32 := make([]float32, min(int(n), readChunk))
+
+	for len(data) < int(n) && d.Err == nil {
+		count := min(int(n)-len(data), readChunk)
+
+		if !narrow {
+			d.Read(buf64[:count])
+			data = append(data, buf64[:count]...)
+			continue
+		}
+
+		d.Read(buf32[:count])
+
+		for _, v := range buf32[:count] {
+			data = append(data, float64(v))
+		}
+	}
+
+	if d.Err != nil {
+		return nil
+	}
+
+	return data
+}
@@ -0,0 +1,75 @@
+package binio
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	data := make([]float64, 2*readChunk+3)
+	for i := range data {
+		data[i] = float64(i) + 0.5
+	}
+
+	enc := Encoder{W: &buf}
+	enc.Strings([]string{"a", "bc"})
+	enc.Floats(data, false)
+	enc.Floats(data[:3], true)
+
+	if enc.Err != nil {
+		t.Fatalf("expected no error, got %v", enc.Err)
+	}
+
+	dec := Decoder{R: &buf}
+	ss := dec.Strings()
+	wide := dec.Floats(uint64(len(data)), false)
+	narrow := dec.Floats(3, true)
+
+	if dec.Err != nil {
+		t.Fatalf("expected no error, got %v", dec.Err)
+	}
+
+	if len(ss) != 2 || ss[1] != "bc" {
+		t.Errorf("expected strings %v, got %v", []string{"a", "bc"}, ss)
+	}
+
+	for i, v := range data {
+		if wide[i] != v {
+			t.Fatalf("expected value %f at %d, got %f", v, i, wide[i])
+		}
+	}
+
+	if narrow[2] != 2.5 {
+		t.Errorf("expected narrowed value %f, got %f", 2.5, narrow[2])
+	}
+}
+
+func TestDecodeCorrupt(t *testing.T) {
+	var buf bytes.Buffer
+
+	enc := Encoder{W: &buf}
+	enc.Write(uint32(MaxLen + 1))
+
+	dec := Decoder{R: bytes.NewReader(buf.Bytes())}
+
+	if dec.Strings(); dec.Err == nil {
+		t.Errorf("expected error for oversized length, got nil")
+	}
+
+	dec = Decoder{R: bytes.NewReader(buf.Bytes())}
+
+	if dec.Floats(4, false); dec.Err == nil {
+		t.Errorf("expected error for unexpected count, got nil")
+	}
+
+	// A count with no values behind it fails without allocating it
+	buf.Reset()
+	enc.Write(uint32(1 << 30))
+	dec = Decoder{R: bytes.NewReader(buf.Bytes())}
+
+	if dec.Floats(1<<30, false); dec.Err == nil {
+		t.Errorf("expected error for missing values, got nil")
+	}
+}
@@ -0,0 +1,132 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrNotPositiveDefinite = errors.New("matrix is not symmetric positive definite")
+
+// Cholesky holds the factorization A = L*L^T of a symmetric positive
+// definite matrix.
+type Cholesky struct {
+	l *Matrix
+}
+
+func (m *Matrix) Cholesky() (*Cholesky, error) {
+	if m.Rows != m.Cols {
+		return nil, fmt.Errorf("cannot factorize: Cholesky requires a square matrix, got %dx%d",
+			m.Rows, m.Cols)
+	}
+
+	if !m.IsSymmetric() {
+		return nil, fmt.Errorf("cannot factorize: %w", ErrNotPositiveDefinite)
+	}
+
+	n := m.Rows
+	l, _ := NewMatrix(n, n)
+	a := m.Data
+	tol := float64(n) * epsilon * maxAbs(a)
+
+	for j := range n {
+		d := a[j*n+j]
+
+		for k := range j {
+			s := a[j*n+k]
+
+			for i := range k {
+				s -= l.Data[k*n+i] * l.Data[j*n+i]
+			}
+
+			s /= l.Data[k*n+k]
+			l.Data[j*n+k] = s
+			d -= s * s
+		}
+
+		if d <= tol {
+			return nil, fmt.Errorf("cannot factorize: non-positive pivot at %d: %w",
+				j, ErrNotPositiveDefinite)
+		}
+
+		l.Data[j*n+j] = math.Sqrt(d)
+	}
+
+	return &Cholesky{l: l}, nil
+}
+
+func (f *Cholesky) L() *Matrix {
+	return f.l.Clone()
+}
+
+func (f *Cholesky) Det() float64 {
+	n := f.l.Rows
+	det := float64(1)
+
+	for i := range n {
+		det *= f.l.Data[i*n+i]
+	}
+
+	return det * det
+}
+
+// Solve returns X such that A*X = b.
+func (f *Cholesky) Solve(b *Matrix) (*Matrix, error) {
+	n := f.l.Rows
+
+	if b.Rows != n {
+		return nil, fmt.Errorf("cannot solve: matrix %dx%d and right-hand side %dx%d",
+			n, n, b.Rows, b.Cols)
+	}
+
+	l := f.l.Data
+	x := b.Clone()
+	nx := x.Cols
+
+	// Solve L*Y = b
+	for k := range n {
+		for j := range nx {
+			s := x.Data[k*nx+j]
+
+			for i := range k {
+				s -= x.Data[i*nx+j] * l[k*n+i]
+			}
+
+			x.Data[k*nx+j] = s / l[k*n+k]
+		}
+	}
+
+	// Solve L^T*X = Y
+	for k := n - 1; k >= 0; k-- {
+		for j := range nx {
+			s := x.Data[k*nx+j]
+
+			for i := k + 1; i < n; i++ {
+				s -= x.Data[i*nx+j] * l[i*n+k]
+			}
+
+			x.Data[k*nx+j] = s / l[k*n+k]
+		}
+	}
+
+	return x, nil
+}
+
+func (m *Matrix) IsSymmetric() bool {
+	if m.Rows != m.Cols {
+		return false
+	}
+
+	n := m.Rows
+	tol := 1e-10 * math.Max(1, maxAbs(m.Data))
+
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			if math.Abs(m.Data[i*n+j]-m.Data[j*n+i]) > tol {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,55 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCholesky(t *testing.T) {
+	a := newTestMatrix(3, 3, 4, 12, -16, 12, 37, -43, -16, -43, 98)
+
+	f, err := a.Cholesky()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertClose(t, f.L(), []float64{2, 0, 0, 6, 1, 0, -8, 5, 3}, 1e-12)
+
+	if math.Abs(f.Det()-36) > 1e-9 {
+		t.Errorf("expected determinant %f, got %f", float64(36), f.Det())
+	}
+
+	b := newTestMatrix(3, 1, 1, 2, 3)
+
+	x, err := f.Solve(b)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ax, _ := a.Multiply(x)
+
+	assertClose(t, ax, b.Data, 1e-9)
+}
+
+func TestCholeskyNotPositiveDefinite(t *testing.T) {
+	a := newTestMatrix(2, 2, 1, 2, 2, 1)
+
+	_, err := a.Cholesky()
+
+	if !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("expected ErrNotPositiveDefinite, got %v", err)
+	}
+}
+
+func TestCholeskyNotSymmetric(t *testing.T) {
+	a := newTestMatrix(2, 2, 4, 1, 2, 3)
+
+	_, err := a.Cholesky()
+
+	if !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("expected ErrNotPositiveDefinite, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var ErrNoConvergence = errors.New("iterative decomposition did not converge")
+
+const maxSweeps = 100
+
+// EigenSym holds the eigendecomposition A = V*diag(Values)*V^T of a
+// symmetric matrix. Values are sorted in descending order and column i of
+// Vectors is the unit eigenvector for Values[i].
+type EigenSym struct {
+	Values  []float64
+	Vectors *Matrix
+}
+
+// EigenSym decomposes a symmetric matrix with the cyclic Jacobi method.
+func (m *Matrix) EigenSym() (*EigenSym, error) {
+	if !m.IsSymmetric() {
+		return nil, fmt.Errorf("cannot decompose: EigenSym requires a symmetric matrix")
+	}
+
+	n := m.Rows
+	a := m.Clone()
+	v := Identity(n)
+
+	// Entries below the roundoff of their diagonal pair, or of the whole
+	// matrix when the pair is near zero, are treated as zero
+	floor := epsilon * math.Sqrt(frobenius2(a.Data))
+	converged := false
+
+	for range maxSweeps {
+		rotated := false
+
+		for p := range n {
+			for q := p + 1; q < n; q++ {
+				apq := a.Data[p*n+q]
+				app, aqq := a.Data[p*n+p], a.Data[q*n+q]
+
+				if math.Abs(apq) <= max(epsilon*math.Sqrt(math.Abs(app*aqq)), floor) {
+					a.Data[p*n+q] = 0
+					a.Data[q*n+p] = 0
+
+					continue
+				}
+
+				theta := (aqq - app) / (2 * apq)
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Hypot(theta, 1))
+				c := 1 / math.Hypot(t, 1)
+				s := t * c
+
+				rotateCols(a, p, q, c, s)
+				rotateRows(a, p, q, c, s)
+				rotateCols(v, p, q, c, s)
+
+				rotated = true
+			}
+		}
+
+		if !rotated {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return nil, fmt.Errorf("failed to decompose symmetric matrix: %w", ErrNoConvergence)
+	}
+
+	values := make([]float64, n)
+	for i := range n {
+		values[i] = a.Data[i*n+i]
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	e := EigenSym{
+		Values:  make([]float64, n),
+		Vectors: permuteCols(v, order),
+	}
+
+	for i, o := range order {
+		e.Values[i] = values[o]
+	}
+
+	return &e, nil
+}
+
+func frobenius2(data []float64) float64 {
+	sum := float64(0)
+
+	for _, s := range data {
+		sum += s * s
+	}
+
+	return sum
+}
+
+// rotateCols applies a Givens rotation to columns p and q of m.
+func rotateCols(m *Matrix, p, q int, c, s float64) {
+	for k := range m.Rows {
+		mp := m.Data[k*m.Cols+p]
+		mq := m.Data[k*m.Cols+q]
+		m.Data[k*m.Cols+p] = c*mp - s*mq
+		m.Data[k*m.Cols+q] = s*mp + c*mq
+	}
+}
+
+// rotateRows applies a Givens rotation to rows p and q of m.
+func rotateRows(m *Matrix, p, q int, c, s float64) {
+	rp := m.Data[p*m.Cols : (p+1)*m.Cols]
+	rq := m.Data[q*m.Cols : (q+1)*m.Cols]
+
+	for k := range rp {
+		mp := rp[k]
+		mq := rq[k]
+		rp[k] = c*mp - s*mq
+		rq[k] = s*mp + c*mq
+	}
+}
+
+func permuteCols(m *Matrix, order []int) *Matrix {
+	result, _ := NewMatrix(m.Rows, len(order))
+
+	for i := range m.Rows {
+		for j, o := range order {
+			result.Data[i*result.Cols+j] = m.Data[i*m.Cols+o]
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,87 @@
+package matrix
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestEigenSym(t *testing.T) {
+	a := newTestMatrix(2, 2, 2, 1, 1, 2)
+
+	e, err := a.EigenSym()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if math.Abs(e.Values[0]-3) > 1e-12 || math.Abs(e.Values[1]-1) > 1e-12 {
+		t.Errorf("expected eigenvalues [3 1], got %v", e.Values)
+	}
+}
+
+func TestEigenSymReconstruct(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	b := randomMatrix(r, 6, 6)
+	a, _ := b.Add(b.Transpose())
+
+	e, err := a.EigenSym()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for i := 1; i < len(e.Values); i++ {
+		if e.Values[i] > e.Values[i-1] {
+			t.Errorf("expected eigenvalues in descending order, got %v", e.Values)
+		}
+	}
+
+	for k, lambda := range e.Values {
+		v, _ := e.Vectors.SliceCol(k)
+		av, _ := a.MulVec(v)
+
+		for i := range v {
+			if math.Abs(av[i]-lambda*v[i]) > 1e-9 {
+				t.Errorf("expected A*v = %f*v for eigenpair %d, got %v", lambda, k, av)
+				break
+			}
+		}
+	}
+}
+
+func TestEigenSymLarge(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	for _, n := range []int{20, 30, 50} {
+		b := randomMatrix(r, n, n)
+		a, _ := b.Add(b.Transpose())
+
+		e, err := a.EigenSym()
+
+		if err != nil {
+			t.Fatalf("%dx%d: expected no error, got %v", n, n, err)
+		}
+
+		// A*V = V*diag(Values)
+		av, _ := a.Multiply(e.Vectors)
+
+		for i := range n {
+			for k, lambda := range e.Values {
+				exp := e.Vectors.Data[i*n+k] * lambda
+
+				if math.Abs(av.Data[i*n+k]-exp) > 1e-9 {
+					t.Fatalf("%dx%d: expected (A*V)[%d,%d] to be %f, got %f", n, n, i, k, exp, av.Data[i*n+k])
+				}
+			}
+		}
+	}
+}
+
+func TestEigenSymNotSymmetric(t *testing.T) {
+	_, err := newTestMatrix(2, 2, 1, 2, 3, 4).EigenSym()
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
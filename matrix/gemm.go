@@ -0,0 +1,160 @@
+package matrix
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+const (
+	// Tile sizes keep a packed panel of B (blockK x blockN) and the matching
+	// slice of each C row resident in L1/L2 while the row block is swept.
+	blockK = 128
+	blockN = 256
+	// Row blocks are the unit of work handed to goroutines.
+	blockRows = 32
+	// Products below this many multiply-adds run on the calling goroutine.
+	parallelThreshold = 1 << 18
+)
+
+func (m *Matrix) MultiplyInto(dst, m2 *Matrix) error {
+	if m.Cols != m2.Rows {
+		return fmt.Errorf("cannot multiply: dimensions: %dx%d and %dx%d",
+			m.Rows, m.Cols, m2.Rows, m2.Cols)
+	}
+
+	err := checkDst("multiply", dst, m.Rows, m2.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	if overlaps(dst.Data, m.Data) || overlaps(dst.Data, m2.Data) {
+		return fmt.Errorf("cannot multiply: destination shares memory with an operand")
+	}
+
+	gemm(dst.Data, m.Data, m2.Data, m.Rows, m.Cols, m2.Cols)
+
+	return nil
+}
+
+// Float is the set of element types the matrix kernels are generic over.
+type Float interface {
+	~float32 | ~float64
+}
+
+// gemm computes c = a*b for row-major a (rows x inner) and b (inner x cols).
+func gemm[T Float](c, a, b []T, rows, inner, cols int) {
+	clear(c)
+
+	if rows == 0 || inner == 0 || cols == 0 {
+		return
+	}
+
+	work := rows * inner * cols
+	workers := runtime.GOMAXPROCS(0)
+
+	if work < parallelThreshold || workers == 1 || rows <= blockRows {
+		gemmRows(c, a, b, inner, cols, 0, rows)
+		return
+	}
+
+	blocks := (rows + blockRows - 1) / blockRows
+	workers = min(workers, blocks)
+
+	next := make(chan int, blocks)
+	for blk := range blocks {
+		next <- blk * blockRows
+	}
+	close(next)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for range workers {
+		go func() {
+			defer wg.Done()
+
+			for r0 := range next {
+				gemmRows(c, a, b, inner, cols, r0, min(r0+blockRows, rows))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// gemmRows accumulates rows [r0, r1) of c += a*b. B is packed one
+// blockK x blockN panel at a time into a contiguous buffer so the inner
+// loop streams it with unit stride.
+func gemmRows[T Float](c, a, b []T, k, n, r0, r1 int) {
+	panel := make([]T, min(blockK, k)*min(blockN, n))
+
+	for jj := 0; jj < n; jj += blockN {
+		nb := min(blockN, n-jj)
+
+		for kk := 0; kk < k; kk += blockK {
+			kb := min(blockK, k-kk)
+			packB(panel, b, n, kk, kb, jj, nb)
+
+			for i := r0; i < r1; i++ {
+				aRow := a[i*k+kk : i*k+kk+kb]
+				cRow := c[i*n+jj : i*n+jj+nb]
+				kernel(cRow, aRow, panel[:kb*nb], nb)
+			}
+		}
+	}
+}
+
+func packB[T Float](panel, b []T, n, kk, kb, jj, nb int) {
+	for p := range kb {
+		src := b[(kk+p)*n+jj : (kk+p)*n+jj+nb]
+		copy(panel[p*nb:(p+1)*nb], src)
+	}
+}
+
+// kernel computes cRow += aRow * panel, where panel is len(aRow) rows of
+// width nb. Four rows of the panel are folded into each pass over cRow to
+// cut loads and stores of the output.
+func kernel[T Float](cRow, aRow, panel []T, nb int) {
+	kb := len(aRow)
+	p := 0
+
+	for ; p+4 <= kb; p += 4 {
+		a0, a1, a2, a3 := aRow[p], aRow[p+1], aRow[p+2], aRow[p+3]
+		b0 := panel[p*nb : (p+1)*nb]
+		b1 := panel[(p+1)*nb : (p+2)*nb]
+		b2 := panel[(p+2)*nb : (p+3)*nb]
+		b3 := panel[(p+3)*nb : (p+4)*nb]
+		out := cRow[:nb]
+
+		for j := range out {
+			out[j] += a0*b0[j] + a1*b1[j] + a2*b2[j] + a3*b3[j]
+		}
+	}
+
+	for ; p < kb; p++ {
+		s := aRow[p]
+		bp := panel[p*nb : (p+1)*nb]
+		out := cRow[:nb]
+
+		for j := range out {
+			out[j] += s * bp[j]
+		}
+	}
+}
+
+func overlaps[T Float](x, y []T) bool {
+	if len(x) == 0 || len(y) == 0 {
+		return false
+	}
+
+	size := unsafe.Sizeof(x[0])
+	xs := uintptr(unsafe.Pointer(&x[0]))
+	ys := uintptr(unsafe.Pointer(&y[0]))
+	xe := xs + uintptr(len(x))*size
+	ye := ys + uintptr(len(y))*size
+
+	return xs < ye && ys < xe
+}
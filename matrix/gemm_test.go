@@ -0,0 +1,160 @@
+package matrix
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func naiveMultiply(m, m2 *Matrix) *Matrix {
+	result, _ := NewMatrix(m.Rows, m2.Cols)
+
+	for i := range m.Rows {
+		for j := range m2.Cols {
+			dotp := float64(0)
+
+			for k := range m.Cols {
+				dotp += m.Data[i*m.Cols+k] * m2.Data[k*m2.Cols+j]
+			}
+
+			result.Data[i*result.Cols+j] = dotp
+		}
+	}
+
+	return result
+}
+
+func randomMatrix(r *rand.Rand, rows, cols int) *Matrix {
+	m, _ := NewMatrix(rows, cols)
+
+	for i := range m.Data {
+		m.Data[i] = r.Float64()*2 - 1
+	}
+
+	return m
+}
+
+func TestMultiplyMatchesNaive(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	dims := [][3]int{
+		{1, 1, 1},
+		{3, 5, 7},
+		{33, 129, 17},
+		{70, 300, 260},
+		{257, 131, 513},
+	}
+
+	for _, d := range dims {
+		m1 := randomMatrix(r, d[0], d[1])
+		m2 := randomMatrix(r, d[1], d[2])
+
+		m, err := m1.Multiply(m2)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		exp := naiveMultiply(m1, m2)
+
+		for i := range exp.Data {
+			if math.Abs(exp.Data[i]-m.Data[i]) > 1e-9 {
+				t.Fatalf("%dx%dx%d: expected Data[%d] to be %f, got %f",
+					d[0], d[1], d[2], i, exp.Data[i], m.Data[i])
+			}
+		}
+	}
+}
+
+func TestMultiplyNonFinite(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+
+	// An inner size of 7 puts columns in both the unrolled and tail loops
+	m1 := randomMatrix(r, 5, 7)
+	m2 := randomMatrix(r, 7, 6)
+
+	m1.Data[1*7+2] = math.Inf(1)
+	m1.Data[3*7+6] = math.NaN()
+
+	for i := range 6 {
+		m1.Data[4*7+i] = 0
+		m2.Data[i*6+1] = 0
+	}
+
+	m2.Data[5*6+3] = math.Inf(-1)
+
+	m, err := m1.Multiply(m2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := naiveMultiply(m1, m2)
+
+	for i := range exp.Data {
+		e, v := exp.Data[i], m.Data[i]
+
+		if math.IsNaN(e) != math.IsNaN(v) || (!math.IsNaN(e) && math.Abs(e-v) > 1e-9 && e != v) {
+			t.Errorf("expected Data[%d] to be %f, got %f", i, e, v)
+		}
+	}
+}
+
+func TestMultiplyInto(t *testing.T) {
+	m1 := newTestMatrix(2, 3, 2, 3, 4, 5, 6, 7)
+	m2 := newTestMatrix(3, 2, 5, 3, 2, 8, 9, 7)
+	dst := newTestMatrix(2, 2, 1, 1, 1, 1)
+
+	err := m1.MultiplyInto(dst, m2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, dst, []float64{52, 58, 100, 112})
+}
+
+func TestMultiplyIntoError(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+	m2 := newTestMatrix(2, 2, 1, 2, 3, 4)
+
+	err := m1.MultiplyInto(newTestMatrix(2, 3), m2)
+
+	if err == nil {
+		t.Errorf("expected dimension error, got nil")
+	}
+
+	err = m1.MultiplyInto(m1, m2)
+
+	if err == nil {
+		t.Errorf("expected aliasing error, got nil")
+	}
+}
+
+func benchmarkMultiply(b *testing.B, n int) {
+	r := rand.New(rand.NewPCG(1, 2))
+	m1 := randomMatrix(r, n, n)
+	m2 := randomMatrix(r, n, n)
+	dst, _ := NewMatrix(n, n)
+
+	for b.Loop() {
+		_ = m1.MultiplyInto(dst, m2)
+	}
+}
+
+func benchmarkNaiveMultiply(b *testing.B, n int) {
+	r := rand.New(rand.NewPCG(1, 2))
+	m1 := randomMatrix(r, n, n)
+	m2 := randomMatrix(r, n, n)
+
+	for b.Loop() {
+		_ = naiveMultiply(m1, m2)
+	}
+}
+
+func BenchmarkMultiply128(b *testing.B)       { benchmarkMultiply(b, 128) }
+func BenchmarkMultiply512(b *testing.B)       { benchmarkMultiply(b, 512) }
+func BenchmarkMultiply1024(b *testing.B)      { benchmarkMultiply(b, 1024) }
+func BenchmarkNaiveMultiply128(b *testing.B)  { benchmarkNaiveMultiply(b, 128) }
+func BenchmarkNaiveMultiply512(b *testing.B)  { benchmarkNaiveMultiply(b, 512) }
+func BenchmarkNaiveMultiply1024(b *testing.B) { benchmarkNaiveMultiply(b, 1024) }
@@ -0,0 +1,129 @@
+package matrix
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Binary layout written by WriteTo: the 4 byte magic, a version byte, rows
+// and cols as little-endian uint64, then Rows*Cols little-endian float64
+// values in row-major order.
+var binaryMagic = [4]byte{'G', 'N', 'M', 'X'}
+
+const binaryVersion = 1
+
+// maxBinaryElements bounds the element count a header may declare. Data is
+// still allocated as it is read, so a corrupt header cannot force a large
+// allocation before the stream runs out.
+const maxBinaryElements uint64 = 1 << 32
+
+// readChunk is the number of elements decoded per read.
+const readChunk = 4096
+
+// validShape reports whether a header's dimensions are within
+// maxBinaryElements and fit in an int on this platform.
+func validShape(rows, cols uint64) bool {
+	if rows > uint64(math.MaxInt) || cols > uint64(math.MaxInt) {
+		return false
+	}
+
+	if cols != 0 && rows > maxBinaryElements/cols {
+		return false
+	}
+
+	return rows*cols <= uint64(math.MaxInt)
+}
+
+func (m *Matrix) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+
+	var header [21]byte
+	copy(header[:4], binaryMagic[:])
+	header[4] = binaryVersion
+	binary.LittleEndian.PutUint64(header[5:13], uint64(m.Rows))
+	binary.LittleEndian.PutUint64(header[13:21], uint64(m.Cols))
+
+	n, err := bw.Write(header[:])
+	written := int64(n)
+
+	if err != nil {
+		return written, fmt.Errorf("failed to write matrix header: %w", err)
+	}
+
+	var buf [8]byte
+
+	for _, v := range m.Data {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+
+		n, err := bw.Write(buf[:])
+		written += int64(n)
+
+		if err != nil {
+			return written, fmt.Errorf("failed to write matrix data: %w", err)
+		}
+	}
+
+	err = bw.Flush()
+
+	if err != nil {
+		return written, fmt.Errorf("failed to write matrix data: %w", err)
+	}
+
+	return written, nil
+}
+
+// ReadFrom replaces m with a matrix previously written by WriteTo.
+func (m *Matrix) ReadFrom(r io.Reader) (int64, error) {
+	var header [21]byte
+
+	n, err := io.ReadFull(r, header[:])
+	read := int64(n)
+
+	if err != nil {
+		return read, fmt.Errorf("failed to read matrix header: %w", err)
+	}
+
+	if [4]byte(header[:4]) != binaryMagic {
+		return read, errors.New("failed to read matrix: not a gonn matrix stream")
+	}
+
+	if header[4] != binaryVersion {
+		return read, fmt.Errorf("failed to read matrix: unsupported version %d", header[4])
+	}
+
+	rows := binary.LittleEndian.Uint64(header[5:13])
+	cols := binary.LittleEndian.Uint64(header[13:21])
+
+	if !validShape(rows, cols) {
+		return read, fmt.Errorf("failed to read matrix: invalid dimensions %dx%d", rows, cols)
+	}
+
+	total := int(rows * cols)
+	data := make([]float64, 0, min(total, readChunk))
+	buf := make([]byte, 8*min(total, readChunk))
+
+	for len(data) < total {
+		chunk := buf[:8*min(total-len(data), readChunk)]
+
+		n, err := io.ReadFull(r, chunk)
+		read += int64(n)
+
+		if err != nil {
+			return read, fmt.Errorf("failed to read matrix data: %w", err)
+		}
+
+		for p := 0; p < len(chunk); p += 8 {
+			data = append(data, math.Float64frombits(binary.LittleEndian.Uint64(chunk[p:])))
+		}
+	}
+
+	m.Rows = int(rows)
+	m.Cols = int(cols)
+	m.Data = data
+
+	return read, nil
+}
@@ -0,0 +1,97 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"runtime"
+	"testing"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	m := newTestMatrix(2, 3, 2, 3, 4, 5, 6, -7.25)
+
+	var buf bytes.Buffer
+
+	n, err := m.WriteTo(&buf)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if n != int64(buf.Len()) || n != 21+6*8 {
+		t.Errorf("expected %d bytes written, got %d", 21+6*8, n)
+	}
+
+	var m2 Matrix
+
+	read, err := m2.ReadFrom(&buf)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if read != n {
+		t.Errorf("expected %d bytes read, got %d", n, read)
+	}
+
+	if !(m2.Rows == 2 && m2.Cols == 3) {
+		t.Errorf("expected dimensions %dx%d, got %dx%d", 2, 3, m2.Rows, m2.Cols)
+	}
+
+	assertData(t, &m2, m.Data)
+}
+
+func TestReadFromError(t *testing.T) {
+	m := newTestMatrix(2, 2, 1, 2, 3, 4)
+
+	var buf bytes.Buffer
+	_, _ = m.WriteTo(&buf)
+
+	var m2 Matrix
+
+	_, err := m2.ReadFrom(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+
+	if err == nil {
+		t.Errorf("expected error for truncated data, got nil")
+	}
+
+	_, err = m2.ReadFrom(bytes.NewReader([]byte("not a matrix stream at all")))
+
+	if err == nil {
+		t.Errorf("expected error for bad magic, got nil")
+	}
+}
+
+func TestReadFromLargeHeader(t *testing.T) {
+	header := make([]byte, 21)
+	copy(header, binaryMagic[:])
+	header[4] = binaryVersion
+	binary.LittleEndian.PutUint64(header[5:13], 1<<16)
+	binary.LittleEndian.PutUint64(header[13:21], 1<<16)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	var m Matrix
+
+	_, err := m.ReadFrom(bytes.NewReader(header))
+
+	runtime.ReadMemStats(&after)
+
+	if err == nil {
+		t.Fatal("expected error for missing data, got nil")
+	}
+
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1<<20 {
+		t.Errorf("expected a small allocation for a header without data, got %d bytes", alloc)
+	}
+
+	binary.LittleEndian.PutUint64(header[5:13], 1<<32)
+	binary.LittleEndian.PutUint64(header[13:21], 2)
+
+	_, err = m.ReadFrom(bytes.NewReader(header))
+
+	if err == nil {
+		t.Error("expected error for too many elements, got nil")
+	}
+}
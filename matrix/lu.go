@@ -0,0 +1,258 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrSingular = errors.New("matrix is singular or ill-conditioned")
+
+// LU holds the factorization P*A = L*U computed with partial pivoting. L
+// (unit diagonal) and U are stored together in lu.
+type LU struct {
+	lu       *Matrix
+	pivot    []int
+	sign     float64
+	singular bool
+}
+
+func (m *Matrix) LU() (*LU, error) {
+	if m.Rows != m.Cols {
+		return nil, fmt.Errorf("cannot factorize: LU requires a square matrix, got %dx%d",
+			m.Rows, m.Cols)
+	}
+
+	n := m.Rows
+	lu := m.Clone()
+	a := lu.Data
+	pivot := make([]int, n)
+	sign := float64(1)
+	singular := false
+
+	for i := range pivot {
+		pivot[i] = i
+	}
+
+	tol := float64(n) * epsilon * maxAbs(a)
+
+	for k := range n {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i*n+k]) > math.Abs(a[p*n+k]) {
+				p = i
+			}
+		}
+
+		if p != k {
+			swapRows(lu, p, k)
+			pivot[p], pivot[k] = pivot[k], pivot[p]
+			sign = -sign
+		}
+
+		d := a[k*n+k]
+
+		if math.Abs(d) <= tol {
+			singular = true
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			a[i*n+k] /= d
+			f := a[i*n+k]
+
+			if f == 0 {
+				continue
+			}
+
+			for j := k + 1; j < n; j++ {
+				a[i*n+j] -= f * a[k*n+j]
+			}
+		}
+	}
+
+	return &LU{
+		lu:       lu,
+		pivot:    pivot,
+		sign:     sign,
+		singular: singular,
+	}, nil
+}
+
+func (f *LU) IsSingular() bool {
+	return f.singular
+}
+
+func (f *LU) Det() float64 {
+	if f.singular {
+		return 0
+	}
+
+	n := f.lu.Rows
+	det := f.sign
+
+	for i := range n {
+		det *= f.lu.Data[i*n+i]
+	}
+
+	return det
+}
+
+func (f *LU) L() *Matrix {
+	n := f.lu.Rows
+	l := Identity(n)
+
+	for i := range n {
+		for j := range i {
+			l.Data[i*n+j] = f.lu.Data[i*n+j]
+		}
+	}
+
+	return l
+}
+
+func (f *LU) U() *Matrix {
+	n := f.lu.Rows
+	u, _ := NewMatrix(n, n)
+
+	for i := range n {
+		for j := i; j < n; j++ {
+			u.Data[i*n+j] = f.lu.Data[i*n+j]
+		}
+	}
+
+	return u
+}
+
+// Pivot returns the row permutation: row i of P*A is row Pivot()[i] of A.
+func (f *LU) Pivot() []int {
+	p := make([]int, len(f.pivot))
+	copy(p, f.pivot)
+
+	return p
+}
+
+// Solve returns X such that A*X = b.
+func (f *LU) Solve(b *Matrix) (*Matrix, error) {
+	n := f.lu.Rows
+
+	if b.Rows != n {
+		return nil, fmt.Errorf("cannot solve: matrix %dx%d and right-hand side %dx%d",
+			n, n, b.Rows, b.Cols)
+	}
+
+	if f.singular {
+		return nil, fmt.Errorf("cannot solve: %w", ErrSingular)
+	}
+
+	x, err := NewMatrix(n, b.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for solve operation: %w", err)
+	}
+
+	nx := x.Cols
+	a := f.lu.Data
+
+	for i, p := range f.pivot {
+		copy(x.Data[i*nx:(i+1)*nx], b.Data[p*nx:(p+1)*nx])
+	}
+
+	// Forward substitution with unit lower triangle
+	for k := range n {
+		for i := k + 1; i < n; i++ {
+			l := a[i*n+k]
+
+			for j := range nx {
+				x.Data[i*nx+j] -= x.Data[k*nx+j] * l
+			}
+		}
+	}
+
+	// Back substitution with upper triangle
+	for k := n - 1; k >= 0; k-- {
+		d := a[k*n+k]
+
+		for j := range nx {
+			x.Data[k*nx+j] /= d
+		}
+
+		for i := range k {
+			u := a[i*n+k]
+
+			for j := range nx {
+				x.Data[i*nx+j] -= x.Data[k*nx+j] * u
+			}
+		}
+	}
+
+	return x, nil
+}
+
+func (f *LU) Inverse() (*Matrix, error) {
+	return f.Solve(Identity(f.lu.Rows))
+}
+
+func (m *Matrix) Det() (float64, error) {
+	f, err := m.LU()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute determinant: %w", err)
+	}
+
+	return f.Det(), nil
+}
+
+func (m *Matrix) Inverse() (*Matrix, error) {
+	f, err := m.LU()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to invert matrix: %w", err)
+	}
+
+	inv, err := f.Inverse()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to invert matrix: %w", err)
+	}
+
+	return inv, nil
+}
+
+// Solve returns X such that m*X = b for square m.
+func (m *Matrix) Solve(b *Matrix) (*Matrix, error) {
+	f, err := m.LU()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve linear system: %w", err)
+	}
+
+	x, err := f.Solve(b)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve linear system: %w", err)
+	}
+
+	return x, nil
+}
+
+const epsilon = 0x1p-52
+
+func maxAbs(data []float64) float64 {
+	v := float64(0)
+
+	for _, s := range data {
+		v = math.Max(v, math.Abs(s))
+	}
+
+	return v
+}
+
+func swapRows(m *Matrix, i, j int) {
+	ri := m.Data[i*m.Cols : (i+1)*m.Cols]
+	rj := m.Data[j*m.Cols : (j+1)*m.Cols]
+
+	for k := range ri {
+		ri[k], rj[k] = rj[k], ri[k]
+	}
+}
@@ -0,0 +1,116 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func assertClose(t *testing.T, m *Matrix, exp []float64, tol float64) {
+	t.Helper()
+
+	if len(m.Data) != len(exp) {
+		t.Fatalf("expected Data length %d, got %d", len(exp), len(m.Data))
+	}
+
+	for i := range exp {
+		if math.Abs(m.Data[i]-exp[i]) > tol {
+			t.Errorf("expected Data[%d] to be %f, got %f", i, exp[i], m.Data[i])
+		}
+	}
+}
+
+func TestLUSolve(t *testing.T) {
+	// [2 1 1] [x]   [5]
+	// [4 -6 0][y] = [-2]
+	// [-2 7 2][z]   [9]
+
+	a := newTestMatrix(3, 3, 2, 1, 1, 4, -6, 0, -2, 7, 2)
+	b := newTestMatrix(3, 1, 5, -2, 9)
+
+	x, err := a.Solve(b)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertClose(t, x, []float64{1, 1, 2}, 1e-12)
+}
+
+func TestLUFactors(t *testing.T) {
+	a := newTestMatrix(3, 3, 2, 1, 1, 4, -6, 0, -2, 7, 2)
+
+	f, err := a.LU()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lu, err := f.L().Multiply(f.U())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := make([]float64, 0, 9)
+	for _, p := range f.Pivot() {
+		row, _ := a.SliceRow(p)
+		exp = append(exp, row...)
+	}
+
+	assertClose(t, lu, exp, 1e-12)
+}
+
+func TestDet(t *testing.T) {
+	a := newTestMatrix(3, 3, 2, 1, 1, 4, -6, 0, -2, 7, 2)
+
+	det, err := a.Det()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if math.Abs(det-(-16)) > 1e-12 {
+		t.Errorf("expected determinant %f, got %f", float64(-16), det)
+	}
+}
+
+func TestInverse(t *testing.T) {
+	a := newTestMatrix(2, 2, 4, 7, 2, 6)
+
+	inv, err := a.Inverse()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertClose(t, inv, []float64{0.6, -0.7, -0.2, 0.4}, 1e-12)
+
+	id, _ := a.Multiply(inv)
+
+	assertClose(t, id, Identity(2).Data, 1e-12)
+}
+
+func TestInverseSingular(t *testing.T) {
+	a := newTestMatrix(2, 2, 1, 2, 2, 4)
+
+	_, err := a.Inverse()
+
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+
+	det, _ := a.Det()
+
+	if det != 0 {
+		t.Errorf("expected determinant 0, got %f", det)
+	}
+}
+
+func TestLUNotSquare(t *testing.T) {
+	_, err := newTestMatrix(2, 3).LU()
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
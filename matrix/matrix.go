@@ -26,6 +26,31 @@ func NewMatrix(rows, cols int) (*Matrix, error) {
 	return &m, nil
 }
 
+func Identity(n int) *Matrix {
+	m, err := NewMatrix(n, n)
+
+	if err != nil {
+		panic("creating a new matrix failed during Identity resulted in fatal error")
+	}
+
+	for i := range n {
+		m.Data[i*n+i] = 1
+	}
+
+	return m
+}
+
+func (m *Matrix) Clone() *Matrix {
+	data := make([]float64, len(m.Data))
+	copy(data, m.Data)
+
+	return &Matrix{
+		Rows: m.Rows,
+		Cols: m.Cols,
+		Data: data,
+	}
+}
+
 func (m *Matrix) At(row, col int) (float64, error) {
 	if !m.validIndex(row, col) {
 		return 0, fmt.Errorf("index out of bounds [%d,%d] for matrix %dx%d",
@@ -94,17 +119,7 @@ func (m *Matrix) Multiply(m2 *Matrix) (*Matrix, error) {
 		return nil, fmt.Errorf("failed to create new matrix for multiply operation: %w", err)
 	}
 
-	for i := range m.Rows {
-		for j := range m2.Cols {
-			dotp := float64(0)
-
-			for k := range m.Cols {
-				dotp += m.Data[i*m.Cols+k] * m2.Data[k*m2.Cols+j]
-			}
-
-			result.Data[i*result.Cols+j] = dotp
-		}
-	}
+	gemm(result.Data, m.Data, m2.Data, m.Rows, m.Cols, m2.Cols)
 
 	return result, nil
 }
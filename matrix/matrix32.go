@@ -0,0 +1,155 @@
+package matrix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matrix32 is the single precision counterpart of Matrix. It halves memory
+// and doubles the elements per SIMD register for large models, and shares
+// the blocked GEMM kernel with Matrix.
+type Matrix32 struct {
+	Rows int
+	Cols int
+	Data []float32
+}
+
+func NewMatrix32(rows, cols int) (*Matrix32, error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("failed to create new matrix with dimensions %dx%d",
+			rows, cols)
+	}
+
+	m := Matrix32{
+		Rows: rows,
+		Cols: cols,
+		Data: make([]float32, rows*cols),
+	}
+
+	return &m, nil
+}
+
+func (m *Matrix32) At(row, col int) (float32, error) {
+	if !m.validIndex(row, col) {
+		return 0, fmt.Errorf("index out of bounds [%d,%d] for matrix %dx%d",
+			row, col, m.Rows, m.Cols)
+	}
+
+	return m.Data[row*m.Cols+col], nil
+}
+
+func (m *Matrix32) Set(row, col int, s float32) error {
+	if !m.validIndex(row, col) {
+		return fmt.Errorf("index out of bounds [%d,%d] for matrix %dx%d",
+			row, col, m.Rows, m.Cols)
+	}
+
+	m.Data[row*m.Cols+col] = s
+
+	return nil
+}
+
+func (m *Matrix32) validIndex(row, col int) bool {
+	return row >= 0 && row < m.Rows && col >= 0 && col < m.Cols
+}
+
+func (m *Matrix32) Multiply(m2 *Matrix32) (*Matrix32, error) {
+	result, err := NewMatrix32(m.Rows, m2.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for multiply operation: %w", err)
+	}
+
+	err = m.MultiplyInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix32) MultiplyInto(dst, m2 *Matrix32) error {
+	if m.Cols != m2.Rows {
+		return fmt.Errorf("cannot multiply: dimensions: %dx%d and %dx%d",
+			m.Rows, m.Cols, m2.Rows, m2.Cols)
+	}
+
+	err := checkDst32("multiply", dst, m.Rows, m2.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	if overlaps(dst.Data, m.Data) || overlaps(dst.Data, m2.Data) {
+		return fmt.Errorf("cannot multiply: destination shares memory with an operand")
+	}
+
+	gemm(dst.Data, m.Data, m2.Data, m.Rows, m.Cols, m2.Cols)
+
+	return nil
+}
+
+func (m *Matrix32) Transpose() *Matrix32 {
+	result, err := NewMatrix32(m.Cols, m.Rows)
+
+	if err != nil {
+		panic("creating a new matrix failed during Transpose resulted in fatal error")
+	}
+
+	for i := range m.Rows {
+		for j := range m.Cols {
+			result.Data[j*m.Rows+i] = m.Data[i*m.Cols+j]
+		}
+	}
+
+	return result
+}
+
+// Float64 converts m to a double precision Matrix.
+func (m *Matrix32) Float64() *Matrix {
+	result := Matrix{
+		Rows: m.Rows,
+		Cols: m.Cols,
+		Data: make([]float64, len(m.Data)),
+	}
+
+	for i, v := range m.Data {
+		result.Data[i] = float64(v)
+	}
+
+	return &result
+}
+
+// Float32 converts m to a single precision Matrix32, rounding each value
+// to the nearest float32.
+func (m *Matrix) Float32() *Matrix32 {
+	result := Matrix32{
+		Rows: m.Rows,
+		Cols: m.Cols,
+		Data: make([]float32, len(m.Data)),
+	}
+
+	for i, v := range m.Data {
+		result.Data[i] = float32(v)
+	}
+
+	return &result
+}
+
+func (m *Matrix32) String() string {
+	var builder strings.Builder
+
+	for i := range m.Rows {
+		builder.WriteString("[")
+		for j := range m.Cols {
+			if j > 0 {
+				builder.WriteString(" ")
+			}
+			builder.WriteString(fmt.Sprintf("%.2f", m.Data[i*m.Cols+j]))
+		}
+		builder.WriteString("]\n")
+	}
+
+	return builder.String()
+}
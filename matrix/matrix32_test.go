@@ -0,0 +1,137 @@
+package matrix
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestMatrix32Multiply(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 10))
+	a := randomMatrix(r, 40, 70)
+	b := randomMatrix(r, 70, 30)
+
+	exp := naiveMultiply(a, b)
+
+	m, err := a.Float32().Multiply(b.Float32())
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got := m.Float64()
+
+	for i := range exp.Data {
+		if math.Abs(exp.Data[i]-got.Data[i]) > 1e-4 {
+			t.Fatalf("expected Data[%d] to be %f, got %f", i, exp.Data[i], got.Data[i])
+		}
+	}
+
+	_, err = a.Float32().Multiply(a.Float32())
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestMatrix32Conversion(t *testing.T) {
+	m := newTestMatrix(2, 3, 2, 3, 4, 5, 6, 7)
+
+	m32 := m.Float32()
+
+	if v, _ := m32.At(1, 2); v != 7 {
+		t.Errorf("expected 1x2 to be %f, got %f", float32(7), v)
+	}
+
+	tr := m32.Transpose()
+
+	if v, _ := tr.At(2, 1); v != 7 {
+		t.Errorf("expected transposed 2x1 to be %f, got %f", float32(7), v)
+	}
+
+	assertData(t, m32.Float64(), m.Data)
+}
+
+func TestMatrix32Ops(t *testing.T) {
+	a := newTestMatrix(2, 3, 1, -2, 3, 4, 5, -6)
+	b := newTestMatrix(2, 3, 0.5, 1, 2, -1, 3, 4)
+	v := []float64{1, 2, 3}
+
+	a32, b32 := a.Float32(), b.Float32()
+	v32 := []float32{1, 2, 3}
+
+	check := func(name string, got *Matrix32, exp *Matrix) {
+		t.Helper()
+
+		for i := range exp.Data {
+			if math.Abs(float64(got.Data[i])-exp.Data[i]) > 1e-5 {
+				t.Errorf("%s: expected Data[%d] to be %f, got %f", name, i, exp.Data[i], got.Data[i])
+			}
+		}
+	}
+
+	sum32, _ := a32.Add(b32)
+	sum, _ := a.Add(b)
+	check("add", sum32, sum)
+
+	diff32, _ := a32.Sub(b32)
+	diff, _ := a.Sub(b)
+	check("sub", diff32, diff)
+
+	had32, _ := a32.Hadamard(b32)
+	had, _ := a.Hadamard(b)
+	check("hadamard", had32, had)
+
+	check("scale", a32.Scale(2.5), a.Scale(2.5))
+
+	axpy32, _ := a32.AddScaled(-0.5, b32)
+	axpy, _ := a.AddScaled(-0.5, b)
+	check("add scaled", axpy32, axpy)
+
+	check("outer", Outer32(v32, v32), Outer(v, v))
+
+	check("apply", a32.Apply(func(x float32) float32 { return x * x }), a.Apply(func(x float64) float64 { return x * x }))
+
+	bcast32, _ := a32.AddRowVector(v32)
+	bcast, _ := a.AddRowVector(v)
+	check("add row vector", bcast32, bcast)
+
+	mv32, err := a32.MulVec(v32)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	mv, _ := a.MulVec(v)
+
+	for name, pair := range map[string][2][]float64{
+		"mul vec":   {widen(mv32), mv},
+		"row sums":  {widen(a32.RowSums()), a.RowSums()},
+		"col sums":  {widen(a32.ColSums()), a.ColSums()},
+		"row means": {widen(a32.RowMeans()), a.RowMeans()},
+		"col means": {widen(a32.ColMeans()), a.ColMeans()},
+	} {
+		for i := range pair[1] {
+			if math.Abs(pair[0][i]-pair[1][i]) > 1e-5 {
+				t.Errorf("%s: expected %v, got %v", name, pair[1], pair[0])
+				break
+			}
+		}
+	}
+
+	_, err = a32.Add(a32.Transpose())
+
+	if err == nil {
+		t.Errorf("expected dimension error, got nil")
+	}
+}
+
+func widen(v []float32) []float64 {
+	result := make([]float64, len(v))
+
+	for i, s := range v {
+		result[i] = float64(s)
+	}
+
+	return result
+}
@@ -0,0 +1,302 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var npyMagic = []byte("\x93NUMPY")
+
+// WriteNPY writes m as a 2-D little-endian float64 NumPy .npy array.
+func (m *Matrix) WriteNPY(w io.Writer) error {
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': (%d, %d), }",
+		m.Rows, m.Cols)
+
+	// Pad so magic, version, length and header end on a 64 byte boundary
+	prefix := len(npyMagic) + 2 + 2
+	pad := 64 - (prefix+len(header)+1)%64
+	if pad == 64 {
+		pad = 0
+	}
+	header += strings.Repeat(" ", pad) + "\n"
+
+	// bufio.Writer keeps the first write error and reports it from Flush
+	bw := bufio.NewWriter(w)
+
+	_, _ = bw.Write(npyMagic)
+	_, _ = bw.Write([]byte{1, 0})
+	_ = binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	_, _ = bw.WriteString(header)
+
+	var buf [8]byte
+
+	for _, v := range m.Data {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		_, _ = bw.Write(buf[:])
+	}
+
+	err := bw.Flush()
+
+	if err != nil {
+		return fmt.Errorf("failed to write npy array: %w", err)
+	}
+
+	return nil
+}
+
+// ReadNPY reads a NumPy .npy array of up to two dimensions. 1-D arrays
+// become a single row and scalars a 1x1 matrix. Float, signed, unsigned
+// and bool dtypes in either byte order are converted to float64.
+func ReadNPY(r io.Reader) (*Matrix, error) {
+	br := bufio.NewReader(r)
+	prefix := make([]byte, len(npyMagic)+2)
+
+	_, err := io.ReadFull(br, prefix)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read npy header: %w", err)
+	}
+
+	if !bytes.Equal(prefix[:len(npyMagic)], npyMagic) {
+		return nil, errors.New("failed to read npy array: missing magic string")
+	}
+
+	var headerLen int
+
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		err = binary.Read(br, binary.LittleEndian, &n)
+		headerLen = int(n)
+	case 2, 3:
+		var n uint32
+		err = binary.Read(br, binary.LittleEndian, &n)
+		headerLen = int(n)
+	default:
+		return nil, fmt.Errorf("failed to read npy array: unsupported version %d", major)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read npy header: %w", err)
+	}
+
+	header := make([]byte, headerLen)
+
+	_, err = io.ReadFull(br, header)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read npy header: %w", err)
+	}
+
+	h, err := parseNPYHeader(string(header))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read npy array: %w", err)
+	}
+
+	rows, cols := 1, 1
+
+	switch len(h.shape) {
+	case 0:
+	case 1:
+		cols = h.shape[0]
+	case 2:
+		rows, cols = h.shape[0], h.shape[1]
+	default:
+		return nil, fmt.Errorf("failed to read npy array: %d dimensions not supported", len(h.shape))
+	}
+
+	if !validShape(uint64(rows), uint64(cols)) {
+		return nil, fmt.Errorf("failed to read npy array: invalid shape %dx%d", rows, cols)
+	}
+
+	total := rows * cols
+	m := &Matrix{
+		Rows: rows,
+		Cols: cols,
+		Data: make([]float64, 0, min(total, readChunk)),
+	}
+
+	buf := make([]byte, h.size)
+
+	for len(m.Data) < total {
+		_, err := io.ReadFull(br, buf)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read npy data: %w", err)
+		}
+
+		m.Data = append(m.Data, h.decode(buf))
+	}
+
+	if h.fortranOrder && len(h.shape) == 2 {
+		t := Matrix{Rows: cols, Cols: rows, Data: m.Data}
+		m = t.Transpose()
+	}
+
+	return m, nil
+}
+
+type npyHeader struct {
+	fortranOrder bool
+	shape        []int
+	size         int
+	decode       func(b []byte) float64
+}
+
+var (
+	npyDescrRe   = regexp.MustCompile(`'descr'\s*:\s*'([<>|=])([fiub])(\d+)'`)
+	npyFortranRe = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapeRe   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+func parseNPYHeader(header string) (*npyHeader, error) {
+	descr := npyDescrRe.FindStringSubmatch(header)
+	fortran := npyFortranRe.FindStringSubmatch(header)
+	shape := npyShapeRe.FindStringSubmatch(header)
+
+	if descr == nil || fortran == nil || shape == nil {
+		return nil, fmt.Errorf("malformed header: %q", strings.TrimSpace(header))
+	}
+
+	h := npyHeader{
+		fortranOrder: fortran[1] == "True",
+	}
+
+	for _, s := range strings.Split(shape[1], ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		d, err := strconv.Atoi(s)
+
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid shape: (%s)", shape[1])
+		}
+
+		h.shape = append(h.shape, d)
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if descr[1] == ">" {
+		order = binary.BigEndian
+	}
+
+	size, _ := strconv.Atoi(descr[3])
+	h.size = size
+
+	switch descr[2] + descr[3] {
+	case "f8":
+		h.decode = func(b []byte) float64 { return math.Float64frombits(order.Uint64(b)) }
+	case "f4":
+		h.decode = func(b []byte) float64 { return float64(math.Float32frombits(order.Uint32(b))) }
+	case "i8":
+		h.decode = func(b []byte) float64 { return float64(int64(order.Uint64(b))) }
+	case "i4":
+		h.decode = func(b []byte) float64 { return float64(int32(order.Uint32(b))) }
+	case "i2":
+		h.decode = func(b []byte) float64 { return float64(int16(order.Uint16(b))) }
+	case "i1":
+		h.decode = func(b []byte) float64 { return float64(int8(b[0])) }
+	case "u8":
+		h.decode = func(b []byte) float64 { return float64(order.Uint64(b)) }
+	case "u4":
+		h.decode = func(b []byte) float64 { return float64(order.Uint32(b)) }
+	case "u2":
+		h.decode = func(b []byte) float64 { return float64(order.Uint16(b)) }
+	case "u1", "b1":
+		h.decode = func(b []byte) float64 { return float64(b[0]) }
+	default:
+		return nil, fmt.Errorf("unsupported dtype: %s", descr[1]+descr[2]+descr[3])
+	}
+
+	return &h, nil
+}
+
+// WriteNPZ writes named matrices as a NumPy .npz archive, one .npy entry
+// per name, optionally deflate compressed (numpy.savez_compressed).
+func WriteNPZ(w io.Writer, arrays map[string]*Matrix, compress bool) error {
+	method := zip.Store
+	if compress {
+		method = zip.Deflate
+	}
+
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+
+	for _, name := range names {
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:   name + ".npy",
+			Method: method,
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to create npz entry %s: %w", name, err)
+		}
+
+		err = arrays[name].WriteNPY(f)
+
+		if err != nil {
+			return fmt.Errorf("failed to write npz entry %s: %w", name, err)
+		}
+	}
+
+	err := zw.Close()
+
+	if err != nil {
+		return fmt.Errorf("failed to write npz archive: %w", err)
+	}
+
+	return nil
+}
+
+// ReadNPZ reads every .npy entry of a NumPy .npz archive, keyed by name
+// without the .npy suffix. Stored and deflated entries are supported.
+func ReadNPZ(r io.ReaderAt, size int64) (map[string]*Matrix, error) {
+	zr, err := zip.NewReader(r, size)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open npz archive: %w", err)
+	}
+
+	arrays := make(map[string]*Matrix, len(zr.File))
+
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".npy") {
+			continue
+		}
+
+		rc, err := f.Open()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to open npz entry %s: %w", f.Name, err)
+		}
+
+		m, err := ReadNPY(rc)
+		_ = rc.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read npz entry %s: %w", f.Name, err)
+		}
+
+		arrays[strings.TrimSuffix(f.Name, ".npy")] = m
+	}
+
+	return arrays, nil
+}
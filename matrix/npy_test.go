@@ -0,0 +1,143 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func npyBytes(header string, data any) []byte {
+	var buf bytes.Buffer
+
+	buf.Write(npyMagic)
+	buf.Write([]byte{1, 0})
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	_ = binary.Write(&buf, binary.LittleEndian, data)
+
+	return buf.Bytes()
+}
+
+func TestWriteNPY(t *testing.T) {
+	m := newTestMatrix(2, 2, 1, 2, 3, 4)
+
+	var buf bytes.Buffer
+
+	err := m.WriteNPY(&buf)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// numpy.save(np.array([[1., 2.], [3., 4.]])) produces a 128 byte header
+	exp := npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2), }"+
+		strings.Repeat(" ", 58)+"\n",
+		[]float64{1, 2, 3, 4})
+
+	if !bytes.Equal(buf.Bytes(), exp) {
+		t.Errorf("expected numpy compatible bytes\n%q\ngot\n%q", exp, buf.Bytes())
+	}
+
+	m2, err := ReadNPY(&buf)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, m2, m.Data)
+}
+
+func TestReadNPYFortranOrder(t *testing.T) {
+	// [[1 2 3]
+	//  [4 5 6]] stored column-major
+	b := npyBytes("{'descr': '<f4', 'fortran_order': True, 'shape': (2, 3), }\n",
+		[]float32{1, 4, 2, 5, 3, 6})
+
+	m, err := ReadNPY(bytes.NewReader(b))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !(m.Rows == 2 && m.Cols == 3) {
+		t.Fatalf("expected dimensions %dx%d, got %dx%d", 2, 3, m.Rows, m.Cols)
+	}
+
+	assertData(t, m, []float64{1, 2, 3, 4, 5, 6})
+}
+
+func TestReadNPYVectorInt(t *testing.T) {
+	b := npyBytes("{'descr': '<i4', 'fortran_order': False, 'shape': (3,), }\n",
+		[]int32{-1, 0, 7})
+
+	m, err := ReadNPY(bytes.NewReader(b))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !(m.Rows == 1 && m.Cols == 3) {
+		t.Fatalf("expected dimensions %dx%d, got %dx%d", 1, 3, m.Rows, m.Cols)
+	}
+
+	assertData(t, m, []float64{-1, 0, 7})
+}
+
+func TestReadNPYError(t *testing.T) {
+	b := npyBytes("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }\n",
+		[]float64{1, 0})
+
+	_, err := ReadNPY(bytes.NewReader(b))
+
+	if err == nil {
+		t.Errorf("expected error for complex dtype, got nil")
+	}
+
+	b = npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2, 2), }\n",
+		make([]float64, 8))
+
+	_, err = ReadNPY(bytes.NewReader(b))
+
+	if err == nil {
+		t.Errorf("expected error for 3-D array, got nil")
+	}
+
+	b = npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (65536, 65536), }\n", []float64{})
+
+	_, err = ReadNPY(bytes.NewReader(b))
+
+	if err == nil {
+		t.Errorf("expected error for missing data, got nil")
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	arrays := map[string]*Matrix{
+		"x": newTestMatrix(2, 2, 1, 2, 3, 4),
+		"y": newTestMatrix(2, 1, 5, 6),
+	}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+
+		err := WriteNPZ(&buf, arrays, compress)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		got, err := ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(got) != 2 {
+			t.Fatalf("expected %d arrays, got %d", 2, len(got))
+		}
+
+		assertData(t, got["x"], arrays["x"].Data)
+		assertData(t, got["y"], arrays["y"].Data)
+	}
+}
@@ -0,0 +1,493 @@
+package matrix
+
+import "fmt"
+
+func (m *Matrix) Add(m2 *Matrix) (*Matrix, error) {
+	result, err := NewMatrix(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for add operation: %w", err)
+	}
+
+	err = m.AddInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix) AddInto(dst, m2 *Matrix) error {
+	err := checkElementwise("add", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	addKernel(dst.Data, m.Data, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix) Sub(m2 *Matrix) (*Matrix, error) {
+	result, err := NewMatrix(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for sub operation: %w", err)
+	}
+
+	err = m.SubInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix) SubInto(dst, m2 *Matrix) error {
+	err := checkElementwise("sub", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	subKernel(dst.Data, m.Data, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix) Hadamard(m2 *Matrix) (*Matrix, error) {
+	result, err := NewMatrix(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for hadamard operation: %w", err)
+	}
+
+	err = m.HadamardInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix) HadamardInto(dst, m2 *Matrix) error {
+	err := checkElementwise("hadamard", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	hadamardKernel(dst.Data, m.Data, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix) Scale(s float64) *Matrix {
+	result, err := NewMatrix(m.Rows, m.Cols)
+
+	if err != nil {
+		panic("creating a new matrix failed during Scale resulted in fatal error")
+	}
+
+	err = m.ScaleInto(result, s)
+
+	if err != nil {
+		panic("scaling matrix resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix) ScaleInto(dst *Matrix, s float64) error {
+	err := checkDst("scale", dst, m.Rows, m.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	scaleKernel(dst.Data, m.Data, s)
+
+	return nil
+}
+
+// AddScaled returns m + alpha*m2 (axpy).
+func (m *Matrix) AddScaled(alpha float64, m2 *Matrix) (*Matrix, error) {
+	result, err := NewMatrix(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for add scaled operation: %w", err)
+	}
+
+	err = m.AddScaledInto(result, alpha, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix) AddScaledInto(dst *Matrix, alpha float64, m2 *Matrix) error {
+	err := checkElementwise("add scaled", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	addScaledKernel(dst.Data, m.Data, alpha, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix) MulVec(v []float64) ([]float64, error) {
+	result := make([]float64, m.Rows)
+
+	err := m.MulVecInto(result, v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix) MulVecInto(dst, v []float64) error {
+	if len(v) != m.Cols {
+		return fmt.Errorf("cannot multiply vector: matrix %dx%d and vector length %d",
+			m.Rows, m.Cols, len(v))
+	}
+
+	if len(dst) != m.Rows {
+		return fmt.Errorf("destination vector length %d does not match matrix rows %d",
+			len(dst), m.Rows)
+	}
+
+	mulVecKernel(dst, m.Data, m.Cols, v)
+
+	return nil
+}
+
+func Outer(u, v []float64) *Matrix {
+	result, err := NewMatrix(len(u), len(v))
+
+	if err != nil {
+		panic("creating a new matrix failed during Outer resulted in fatal error")
+	}
+
+	err = OuterInto(result, u, v)
+
+	if err != nil {
+		panic("computing outer product resulted in fatal error")
+	}
+
+	return result
+}
+
+func OuterInto(dst *Matrix, u, v []float64) error {
+	err := checkDst("outer", dst, len(u), len(v))
+
+	if err != nil {
+		return err
+	}
+
+	outerKernel(dst.Data, u, v)
+
+	return nil
+}
+
+func (m *Matrix) Apply(fn func(v float64) float64) *Matrix {
+	result, err := NewMatrix(m.Rows, m.Cols)
+
+	if err != nil {
+		panic("creating a new matrix failed during Apply resulted in fatal error")
+	}
+
+	err = m.ApplyInto(result, fn)
+
+	if err != nil {
+		panic("applying function to matrix resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix) ApplyInto(dst *Matrix, fn func(v float64) float64) error {
+	err := checkDst("apply", dst, m.Rows, m.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	applyKernel(dst.Data, m.Data, fn)
+
+	return nil
+}
+
+func (m *Matrix) RowSums() []float64 {
+	result := make([]float64, m.Rows)
+
+	err := m.RowSumsInto(result)
+
+	if err != nil {
+		panic("summing matrix rows resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix) RowSumsInto(dst []float64) error {
+	if len(dst) != m.Rows {
+		return fmt.Errorf("destination vector length %d does not match matrix rows %d",
+			len(dst), m.Rows)
+	}
+
+	rowSumsKernel(dst, m.Data, m.Cols)
+
+	return nil
+}
+
+func (m *Matrix) ColSums() []float64 {
+	result := make([]float64, m.Cols)
+
+	err := m.ColSumsInto(result)
+
+	if err != nil {
+		panic("summing matrix columns resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix) ColSumsInto(dst []float64) error {
+	if len(dst) != m.Cols {
+		return fmt.Errorf("destination vector length %d does not match matrix columns %d",
+			len(dst), m.Cols)
+	}
+
+	colSumsKernel(dst, m.Data, m.Cols)
+
+	return nil
+}
+
+func (m *Matrix) RowMeans() []float64 {
+	result := make([]float64, m.Rows)
+
+	err := m.RowMeansInto(result)
+
+	if err != nil {
+		panic("averaging matrix rows resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix) RowMeansInto(dst []float64) error {
+	err := m.RowSumsInto(dst)
+
+	if err != nil {
+		return err
+	}
+
+	if m.Cols == 0 {
+		return nil
+	}
+
+	for i := range dst {
+		dst[i] /= float64(m.Cols)
+	}
+
+	return nil
+}
+
+func (m *Matrix) ColMeans() []float64 {
+	result := make([]float64, m.Cols)
+
+	err := m.ColMeansInto(result)
+
+	if err != nil {
+		panic("averaging matrix columns resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix) ColMeansInto(dst []float64) error {
+	err := m.ColSumsInto(dst)
+
+	if err != nil {
+		return err
+	}
+
+	if m.Rows == 0 {
+		return nil
+	}
+
+	for i := range dst {
+		dst[i] /= float64(m.Rows)
+	}
+
+	return nil
+}
+
+// AddRowVector adds v to every row of m (broadcasting).
+func (m *Matrix) AddRowVector(v []float64) (*Matrix, error) {
+	result, err := NewMatrix(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for add row vector operation: %w", err)
+	}
+
+	err = m.AddRowVectorInto(result, v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix) AddRowVectorInto(dst *Matrix, v []float64) error {
+	if len(v) != m.Cols {
+		return fmt.Errorf("cannot broadcast vector length %d over matrix %dx%d",
+			len(v), m.Rows, m.Cols)
+	}
+
+	err := checkDst("add row vector", dst, m.Rows, m.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	addRowVectorKernel(dst.Data, m.Data, v)
+
+	return nil
+}
+
+func checkElementwise(op string, dst, m, m2 *Matrix) error {
+	return checkShapes(op, dst.Rows, dst.Cols, m.Rows, m.Cols, m2.Rows, m2.Cols)
+}
+
+func checkDst(op string, dst *Matrix, rows, cols int) error {
+	return checkDstShape(op, dst.Rows, dst.Cols, rows, cols)
+}
+
+func checkShapes(op string, dstRows, dstCols, rows, cols, rows2, cols2 int) error {
+	if rows != rows2 || cols != cols2 {
+		return fmt.Errorf("cannot %s: dimensions: %dx%d and %dx%d",
+			op, rows, cols, rows2, cols2)
+	}
+
+	return checkDstShape(op, dstRows, dstCols, rows, cols)
+}
+
+func checkDstShape(op string, dstRows, dstCols, rows, cols int) error {
+	if dstRows != rows || dstCols != cols {
+		return fmt.Errorf("cannot %s: destination dimensions %dx%d, expected %dx%d",
+			op, dstRows, dstCols, rows, cols)
+	}
+
+	return nil
+}
+
+// The kernels below are shared by Matrix and Matrix32. Callers check the
+// shapes first.
+
+func addKernel[T Float](dst, a, b []T) {
+	for i := range a {
+		dst[i] = a[i] + b[i]
+	}
+}
+
+func subKernel[T Float](dst, a, b []T) {
+	for i := range a {
+		dst[i] = a[i] - b[i]
+	}
+}
+
+func hadamardKernel[T Float](dst, a, b []T) {
+	for i := range a {
+		dst[i] = a[i] * b[i]
+	}
+}
+
+func scaleKernel[T Float](dst, a []T, s T) {
+	for i, v := range a {
+		dst[i] = v * s
+	}
+}
+
+func addScaledKernel[T Float](dst, a []T, alpha T, b []T) {
+	for i := range a {
+		dst[i] = a[i] + alpha*b[i]
+	}
+}
+
+// mulVecKernel sets dst to a*v for a row-major a with cols columns.
+func mulVecKernel[T Float](dst, a []T, cols int, v []T) {
+	for i := range dst {
+		row := a[i*cols : (i+1)*cols]
+		sum := T(0)
+
+		for j, s := range row {
+			sum += s * v[j]
+		}
+
+		dst[i] = sum
+	}
+}
+
+func outerKernel[T Float](dst, u, v []T) {
+	for i, a := range u {
+		row := dst[i*len(v) : (i+1)*len(v)]
+
+		for j, b := range v {
+			row[j] = a * b
+		}
+	}
+}
+
+func applyKernel[T Float](dst, a []T, fn func(v T) T) {
+	for i, v := range a {
+		dst[i] = fn(v)
+	}
+}
+
+func rowSumsKernel[T Float](dst, a []T, cols int) {
+	for i := range dst {
+		sum := T(0)
+
+		for _, v := range a[i*cols : (i+1)*cols] {
+			sum += v
+		}
+
+		dst[i] = sum
+	}
+}
+
+func colSumsKernel[T Float](dst, a []T, cols int) {
+	clear(dst)
+
+	for i := 0; i < len(a); i += cols {
+		for j, v := range a[i : i+cols] {
+			dst[j] += v
+		}
+	}
+}
+
+// addRowVectorKernel adds v to every row of a.
+func addRowVectorKernel[T Float](dst, a, v []T) {
+	cols := len(v)
+
+	for i := 0; i < len(a); i += cols {
+		src := a[i : i+cols]
+		out := dst[i : i+cols]
+
+		for j, s := range src {
+			out[j] = s + v[j]
+		}
+	}
+}
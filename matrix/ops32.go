@@ -0,0 +1,394 @@
+package matrix
+
+import "fmt"
+
+// The Matrix32 operations mirror those of Matrix and share their kernels.
+
+func (m *Matrix32) Add(m2 *Matrix32) (*Matrix32, error) {
+	result, err := NewMatrix32(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for add operation: %w", err)
+	}
+
+	err = m.AddInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix32) AddInto(dst, m2 *Matrix32) error {
+	err := checkElementwise32("add", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	addKernel(dst.Data, m.Data, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix32) Sub(m2 *Matrix32) (*Matrix32, error) {
+	result, err := NewMatrix32(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for sub operation: %w", err)
+	}
+
+	err = m.SubInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix32) SubInto(dst, m2 *Matrix32) error {
+	err := checkElementwise32("sub", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	subKernel(dst.Data, m.Data, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix32) Hadamard(m2 *Matrix32) (*Matrix32, error) {
+	result, err := NewMatrix32(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for hadamard operation: %w", err)
+	}
+
+	err = m.HadamardInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix32) HadamardInto(dst, m2 *Matrix32) error {
+	err := checkElementwise32("hadamard", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	hadamardKernel(dst.Data, m.Data, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix32) Scale(s float32) *Matrix32 {
+	result, err := NewMatrix32(m.Rows, m.Cols)
+
+	if err != nil {
+		panic("creating a new matrix failed during Scale resulted in fatal error")
+	}
+
+	err = m.ScaleInto(result, s)
+
+	if err != nil {
+		panic("scaling matrix resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix32) ScaleInto(dst *Matrix32, s float32) error {
+	err := checkDst32("scale", dst, m.Rows, m.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	scaleKernel(dst.Data, m.Data, s)
+
+	return nil
+}
+
+// AddScaled returns m + alpha*m2 (axpy).
+func (m *Matrix32) AddScaled(alpha float32, m2 *Matrix32) (*Matrix32, error) {
+	result, err := NewMatrix32(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for add scaled operation: %w", err)
+	}
+
+	err = m.AddScaledInto(result, alpha, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix32) AddScaledInto(dst *Matrix32, alpha float32, m2 *Matrix32) error {
+	err := checkElementwise32("add scaled", dst, m, m2)
+
+	if err != nil {
+		return err
+	}
+
+	addScaledKernel(dst.Data, m.Data, alpha, m2.Data)
+
+	return nil
+}
+
+func (m *Matrix32) MulVec(v []float32) ([]float32, error) {
+	result := make([]float32, m.Rows)
+
+	err := m.MulVecInto(result, v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix32) MulVecInto(dst, v []float32) error {
+	if len(v) != m.Cols {
+		return fmt.Errorf("cannot multiply vector: matrix %dx%d and vector length %d",
+			m.Rows, m.Cols, len(v))
+	}
+
+	if len(dst) != m.Rows {
+		return fmt.Errorf("destination vector length %d does not match matrix rows %d",
+			len(dst), m.Rows)
+	}
+
+	mulVecKernel(dst, m.Data, m.Cols, v)
+
+	return nil
+}
+
+func Outer32(u, v []float32) *Matrix32 {
+	result, err := NewMatrix32(len(u), len(v))
+
+	if err != nil {
+		panic("creating a new matrix failed during Outer32 resulted in fatal error")
+	}
+
+	err = Outer32Into(result, u, v)
+
+	if err != nil {
+		panic("computing outer product resulted in fatal error")
+	}
+
+	return result
+}
+
+func Outer32Into(dst *Matrix32, u, v []float32) error {
+	err := checkDst32("outer", dst, len(u), len(v))
+
+	if err != nil {
+		return err
+	}
+
+	outerKernel(dst.Data, u, v)
+
+	return nil
+}
+
+func (m *Matrix32) Apply(fn func(v float32) float32) *Matrix32 {
+	result, err := NewMatrix32(m.Rows, m.Cols)
+
+	if err != nil {
+		panic("creating a new matrix failed during Apply resulted in fatal error")
+	}
+
+	err = m.ApplyInto(result, fn)
+
+	if err != nil {
+		panic("applying function to matrix resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix32) ApplyInto(dst *Matrix32, fn func(v float32) float32) error {
+	err := checkDst32("apply", dst, m.Rows, m.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	applyKernel(dst.Data, m.Data, fn)
+
+	return nil
+}
+
+func (m *Matrix32) RowSums() []float32 {
+	result := make([]float32, m.Rows)
+
+	err := m.RowSumsInto(result)
+
+	if err != nil {
+		panic("summing matrix rows resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix32) RowSumsInto(dst []float32) error {
+	if len(dst) != m.Rows {
+		return fmt.Errorf("destination vector length %d does not match matrix rows %d",
+			len(dst), m.Rows)
+	}
+
+	rowSumsKernel(dst, m.Data, m.Cols)
+
+	return nil
+}
+
+func (m *Matrix32) ColSums() []float32 {
+	result := make([]float32, m.Cols)
+
+	err := m.ColSumsInto(result)
+
+	if err != nil {
+		panic("summing matrix columns resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix32) ColSumsInto(dst []float32) error {
+	if len(dst) != m.Cols {
+		return fmt.Errorf("destination vector length %d does not match matrix columns %d",
+			len(dst), m.Cols)
+	}
+
+	colSumsKernel(dst, m.Data, m.Cols)
+
+	return nil
+}
+
+func (m *Matrix32) RowMeans() []float32 {
+	result := make([]float32, m.Rows)
+
+	err := m.RowMeansInto(result)
+
+	if err != nil {
+		panic("averaging matrix rows resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix32) RowMeansInto(dst []float32) error {
+	err := m.RowSumsInto(dst)
+
+	if err != nil {
+		return err
+	}
+
+	if m.Cols == 0 {
+		return nil
+	}
+
+	for i := range dst {
+		dst[i] /= float32(m.Cols)
+	}
+
+	return nil
+}
+
+func (m *Matrix32) ColMeans() []float32 {
+	result := make([]float32, m.Cols)
+
+	err := m.ColMeansInto(result)
+
+	if err != nil {
+		panic("averaging matrix columns resulted in fatal error")
+	}
+
+	return result
+}
+
+func (m *Matrix32) ColMeansInto(dst []float32) error {
+	err := m.ColSumsInto(dst)
+
+	if err != nil {
+		return err
+	}
+
+	if m.Rows == 0 {
+		return nil
+	}
+
+	for i := range dst {
+		dst[i] /= float32(m.Rows)
+	}
+
+	return nil
+}
+
+// AddRowVector adds v to every row of m (broadcasting).
+func (m *Matrix32) AddRowVector(v []float32) (*Matrix32, error) {
+	result, err := NewMatrix32(m.Rows, m.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for add row vector operation: %w", err)
+	}
+
+	err = m.AddRowVectorInto(result, v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (m *Matrix32) AddRowVectorInto(dst *Matrix32, v []float32) error {
+	if len(v) != m.Cols {
+		return fmt.Errorf("cannot broadcast vector length %d over matrix %dx%d",
+			len(v), m.Rows, m.Cols)
+	}
+
+	err := checkDst32("add row vector", dst, m.Rows, m.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	addRowVectorKernel(dst.Data, m.Data, v)
+
+	return nil
+}
+
+// RowView returns row of m as a slice sharing m.Data.
+func (m *Matrix32) RowView(row int) ([]float32, error) {
+	if row < 0 || row >= m.Rows {
+		return nil, fmt.Errorf("row index %d out of bounds for matrix %dx%d",
+			row, m.Rows, m.Cols)
+	}
+
+	return m.Data[row*m.Cols : (row+1)*m.Cols : (row+1)*m.Cols], nil
+}
+
+// AtUnchecked returns the element at (row, col) without bounds checking
+// the indices.
+func (m *Matrix32) AtUnchecked(row, col int) float32 {
+	return m.Data[row*m.Cols+col]
+}
+
+func checkElementwise32(op string, dst, m, m2 *Matrix32) error {
+	return checkShapes(op, dst.Rows, dst.Cols, m.Rows, m.Cols, m2.Rows, m2.Cols)
+}
+
+func checkDst32(op string, dst *Matrix32, rows, cols int) error {
+	return checkDstShape(op, dst.Rows, dst.Cols, rows, cols)
+}
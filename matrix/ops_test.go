@@ -0,0 +1,215 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func newTestMatrix(rows, cols int, data ...float64) *Matrix {
+	m, _ := NewMatrix(rows, cols)
+	copy(m.Data, data)
+
+	return m
+}
+
+func assertData(t *testing.T, m *Matrix, exp []float64) {
+	t.Helper()
+
+	if len(m.Data) != len(exp) {
+		t.Fatalf("expected Data length %d, got %d", len(exp), len(m.Data))
+	}
+
+	for i := range exp {
+		if m.Data[i] != exp[i] {
+			t.Errorf("expected Data[%d] to be %f, got %f", i, exp[i], m.Data[i])
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+	m2 := newTestMatrix(2, 2, 5, 6, 7, 8)
+
+	m, err := m1.Add(m2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, m, []float64{6, 8, 10, 12})
+}
+
+func TestAddError(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+	m2 := newTestMatrix(2, 3)
+
+	_, err := m1.Add(m2)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestAddIntoSelf(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+	m2 := newTestMatrix(2, 2, 1, 1, 1, 1)
+
+	err := m1.AddInto(m1, m2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, m1, []float64{2, 3, 4, 5})
+}
+
+func TestAddIntoDstError(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+	dst := newTestMatrix(1, 4)
+
+	err := m1.AddInto(dst, m1)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestSub(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 5, 6, 7, 8)
+	m2 := newTestMatrix(2, 2, 1, 2, 3, 4)
+
+	m, err := m1.Sub(m2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, m, []float64{4, 4, 4, 4})
+}
+
+func TestHadamard(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+	m2 := newTestMatrix(2, 2, 5, 6, 7, 8)
+
+	m, err := m1.Hadamard(m2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, m, []float64{5, 12, 21, 32})
+}
+
+func TestScale(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+
+	m := m1.Scale(2)
+
+	assertData(t, m, []float64{2, 4, 6, 8})
+}
+
+func TestAddScaled(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+	m2 := newTestMatrix(2, 2, 1, 1, 1, 1)
+
+	m, err := m1.AddScaled(-0.5, m2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, m, []float64{0.5, 1.5, 2.5, 3.5})
+}
+
+func TestMulVec(t *testing.T) {
+	// Input M1
+	// [2 3 4]
+	// [5 6 7]
+
+	m1 := newTestMatrix(2, 3, 2, 3, 4, 5, 6, 7)
+
+	v, err := m1.MulVec([]float64{1, 0, 2})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if v[0] != 10 || v[1] != 19 {
+		t.Errorf("expected vector [10 19], got %v", v)
+	}
+
+	_, err = m1.MulVec([]float64{1, 2})
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestOuter(t *testing.T) {
+	m := Outer([]float64{1, 2}, []float64{3, 4, 5})
+
+	if !(m.Rows == 2 && m.Cols == 3) {
+		t.Fatalf("expected dimensions %dx%d, got %dx%d", 2, 3, m.Rows, m.Cols)
+	}
+
+	assertData(t, m, []float64{3, 4, 5, 6, 8, 10})
+}
+
+func TestApply(t *testing.T) {
+	m1 := newTestMatrix(2, 2, 1, 2, 3, 4)
+
+	m := m1.Apply(func(v float64) float64 {
+		return v * v
+	})
+
+	assertData(t, m, []float64{1, 4, 9, 16})
+}
+
+func TestRowAndColSums(t *testing.T) {
+	// Input M1
+	// [2 3 4]
+	// [5 6 7]
+
+	m1 := newTestMatrix(2, 3, 2, 3, 4, 5, 6, 7)
+
+	rows := m1.RowSums()
+
+	if rows[0] != 9 || rows[1] != 18 {
+		t.Errorf("expected row sums [9 18], got %v", rows)
+	}
+
+	cols := m1.ColSums()
+
+	if cols[0] != 7 || cols[1] != 9 || cols[2] != 11 {
+		t.Errorf("expected column sums [7 9 11], got %v", cols)
+	}
+
+	rowMeans := m1.RowMeans()
+
+	if rowMeans[0] != 3 || rowMeans[1] != 6 {
+		t.Errorf("expected row means [3 6], got %v", rowMeans)
+	}
+
+	colMeans := m1.ColMeans()
+
+	if colMeans[0] != 3.5 || colMeans[1] != 4.5 || colMeans[2] != 5.5 {
+		t.Errorf("expected column means [3.5 4.5 5.5], got %v", colMeans)
+	}
+}
+
+func TestAddRowVector(t *testing.T) {
+	m1 := newTestMatrix(2, 3, 2, 3, 4, 5, 6, 7)
+
+	m, err := m1.AddRowVector([]float64{1, 2, 3})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, m, []float64{3, 5, 7, 6, 8, 10})
+
+	_, err = m1.AddRowVector([]float64{1, 2})
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -0,0 +1,199 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// QR holds a Householder factorization A = Q*R of an m x n matrix with
+// m >= n. The Householder vectors live below the diagonal of qr and the
+// diagonal of R is kept separately in rDiag.
+type QR struct {
+	qr    *Matrix
+	rDiag []float64
+}
+
+func (m *Matrix) QR() (*QR, error) {
+	if m.Rows < m.Cols {
+		return nil, fmt.Errorf("cannot factorize: QR requires rows >= cols, got %dx%d",
+			m.Rows, m.Cols)
+	}
+
+	qr := m.Clone()
+	a := qr.Data
+	rows, cols := m.Rows, m.Cols
+	rDiag := make([]float64, cols)
+
+	for k := range cols {
+		nrm := float64(0)
+
+		for i := k; i < rows; i++ {
+			nrm = math.Hypot(nrm, a[i*cols+k])
+		}
+
+		if nrm != 0 {
+			if a[k*cols+k] < 0 {
+				nrm = -nrm
+			}
+
+			for i := k; i < rows; i++ {
+				a[i*cols+k] /= nrm
+			}
+
+			a[k*cols+k]++
+
+			for j := k + 1; j < cols; j++ {
+				s := float64(0)
+
+				for i := k; i < rows; i++ {
+					s += a[i*cols+k] * a[i*cols+j]
+				}
+
+				s = -s / a[k*cols+k]
+
+				for i := k; i < rows; i++ {
+					a[i*cols+j] += s * a[i*cols+k]
+				}
+			}
+		}
+
+		rDiag[k] = -nrm
+	}
+
+	return &QR{
+		qr:    qr,
+		rDiag: rDiag,
+	}, nil
+}
+
+func (f *QR) IsFullRank() bool {
+	tol := float64(f.qr.Rows) * epsilon * maxAbs(f.rDiag)
+
+	for _, d := range f.rDiag {
+		if math.Abs(d) <= tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Q returns the thin, column-orthonormal m x n factor.
+func (f *QR) Q() *Matrix {
+	rows, cols := f.qr.Rows, f.qr.Cols
+	a := f.qr.Data
+	q, _ := NewMatrix(rows, cols)
+
+	for k := cols - 1; k >= 0; k-- {
+		q.Data[k*cols+k] = 1
+
+		for j := k; j < cols; j++ {
+			if a[k*cols+k] == 0 {
+				continue
+			}
+
+			s := float64(0)
+
+			for i := k; i < rows; i++ {
+				s += a[i*cols+k] * q.Data[i*cols+j]
+			}
+
+			s = -s / a[k*cols+k]
+
+			for i := k; i < rows; i++ {
+				q.Data[i*cols+j] += s * a[i*cols+k]
+			}
+		}
+	}
+
+	return q
+}
+
+// R returns the n x n upper triangular factor.
+func (f *QR) R() *Matrix {
+	cols := f.qr.Cols
+	r, _ := NewMatrix(cols, cols)
+
+	for i := range cols {
+		r.Data[i*cols+i] = f.rDiag[i]
+
+		for j := i + 1; j < cols; j++ {
+			r.Data[i*cols+j] = f.qr.Data[i*cols+j]
+		}
+	}
+
+	return r
+}
+
+// Solve returns the least squares solution X minimizing ||A*X - b||.
+func (f *QR) Solve(b *Matrix) (*Matrix, error) {
+	rows, cols := f.qr.Rows, f.qr.Cols
+
+	if b.Rows != rows {
+		return nil, fmt.Errorf("cannot solve: matrix %dx%d and right-hand side %dx%d",
+			rows, cols, b.Rows, b.Cols)
+	}
+
+	if !f.IsFullRank() {
+		return nil, fmt.Errorf("cannot solve: matrix is rank deficient: %w", ErrSingular)
+	}
+
+	a := f.qr.Data
+	x := b.Clone()
+	nx := x.Cols
+
+	// Apply Q^T to b
+	for k := range cols {
+		for j := range nx {
+			s := float64(0)
+
+			for i := k; i < rows; i++ {
+				s += a[i*cols+k] * x.Data[i*nx+j]
+			}
+
+			s = -s / a[k*cols+k]
+
+			for i := k; i < rows; i++ {
+				x.Data[i*nx+j] += s * a[i*cols+k]
+			}
+		}
+	}
+
+	// Back substitution with R
+	for k := cols - 1; k >= 0; k-- {
+		for j := range nx {
+			x.Data[k*nx+j] /= f.rDiag[k]
+		}
+
+		for i := range k {
+			r := a[i*cols+k]
+
+			for j := range nx {
+				x.Data[i*nx+j] -= x.Data[k*nx+j] * r
+			}
+		}
+	}
+
+	x.Data = x.Data[:cols*nx]
+	x.Rows = cols
+
+	return x, nil
+}
+
+// LeastSquares returns X minimizing ||m*X - b||, e.g. closed-form linear
+// regression weights when m is a design matrix.
+func (m *Matrix) LeastSquares(b *Matrix) (*Matrix, error) {
+	f, err := m.QR()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve least squares: %w", err)
+	}
+
+	x, err := f.Solve(b)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve least squares: %w", err)
+	}
+
+	return x, nil
+}
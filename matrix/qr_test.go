@@ -0,0 +1,72 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQRFactors(t *testing.T) {
+	a := newTestMatrix(4, 3, 12, -51, 4, 6, 167, -68, -4, 24, -41, 1, 2, 3)
+
+	f, err := a.QR()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	q := f.Q()
+	r := f.R()
+
+	qr, _ := q.Multiply(r)
+
+	assertClose(t, qr, a.Data, 1e-9)
+
+	qtq, _ := q.Transpose().Multiply(q)
+
+	assertClose(t, qtq, Identity(3).Data, 1e-12)
+
+	for i := range r.Rows {
+		for j := range i {
+			if v, _ := r.At(i, j); v != 0 {
+				t.Errorf("expected R to be upper triangular, got %f at (%d, %d)", v, i, j)
+			}
+		}
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	// Fit y = 1 + 2x exactly through four points
+	a := newTestMatrix(4, 2, 1, 0, 1, 1, 1, 2, 1, 3)
+	b := newTestMatrix(4, 1, 1, 3, 5, 7)
+
+	x, err := a.LeastSquares(b)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !(x.Rows == 2 && x.Cols == 1) {
+		t.Fatalf("expected dimensions %dx%d, got %dx%d", 2, 1, x.Rows, x.Cols)
+	}
+
+	assertClose(t, x, []float64{1, 2}, 1e-12)
+}
+
+func TestLeastSquaresRankDeficient(t *testing.T) {
+	a := newTestMatrix(3, 2, 1, 2, 2, 4, 3, 6)
+	b := newTestMatrix(3, 1, 1, 2, 3)
+
+	_, err := a.LeastSquares(b)
+
+	if !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+}
+
+func TestQRWide(t *testing.T) {
+	_, err := newTestMatrix(2, 3).QR()
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
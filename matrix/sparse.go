@@ -0,0 +1,381 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Triplet is a single (row, col, value) entry used to build sparse matrices.
+type Triplet struct {
+	Row   int
+	Col   int
+	Value float64
+}
+
+// CSR is a compressed sparse row matrix. The non-zeros of row i are
+// Data[Indptr[i]:Indptr[i+1]] at columns Indices[Indptr[i]:Indptr[i+1]],
+// sorted by column.
+type CSR struct {
+	Rows    int
+	Cols    int
+	Indptr  []int
+	Indices []int
+	Data    []float64
+}
+
+// CSC is a compressed sparse column matrix, the transpose layout of CSR.
+type CSC struct {
+	Rows    int
+	Cols    int
+	Indptr  []int
+	Indices []int
+	Data    []float64
+}
+
+// NewCSR builds a CSR matrix from triplets. Duplicate entries are summed
+// and explicit zeros are dropped.
+func NewCSR(rows, cols int, triplets []Triplet) (*CSR, error) {
+	indptr, indices, data, err := compress(rows, cols, triplets, false)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSR matrix: %w", err)
+	}
+
+	return &CSR{
+		Rows:    rows,
+		Cols:    cols,
+		Indptr:  indptr,
+		Indices: indices,
+		Data:    data,
+	}, nil
+}
+
+// NewCSC builds a CSC matrix from triplets. Duplicate entries are summed
+// and explicit zeros are dropped.
+func NewCSC(rows, cols int, triplets []Triplet) (*CSC, error) {
+	indptr, indices, data, err := compress(rows, cols, triplets, true)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSC matrix: %w", err)
+	}
+
+	return &CSC{
+		Rows:    rows,
+		Cols:    cols,
+		Indptr:  indptr,
+		Indices: indices,
+		Data:    data,
+	}, nil
+}
+
+func compress(rows, cols int, triplets []Triplet, byCol bool) ([]int, []int, []float64, error) {
+	if rows < 0 || cols < 0 {
+		return nil, nil, nil, fmt.Errorf("invalid dimensions %dx%d", rows, cols)
+	}
+
+	for _, t := range triplets {
+		if t.Row < 0 || t.Row >= rows || t.Col < 0 || t.Col >= cols {
+			return nil, nil, nil, fmt.Errorf("index out of bounds [%d,%d] for matrix %dx%d",
+				t.Row, t.Col, rows, cols)
+		}
+	}
+
+	major, minor := func(t Triplet) int { return t.Row }, func(t Triplet) int { return t.Col }
+	n := rows
+
+	if byCol {
+		major, minor = minor, major
+		n = cols
+	}
+
+	sorted := make([]Triplet, len(triplets))
+	copy(sorted, triplets)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if major(sorted[i]) != major(sorted[j]) {
+			return major(sorted[i]) < major(sorted[j])
+		}
+
+		return minor(sorted[i]) < minor(sorted[j])
+	})
+
+	indptr := make([]int, n+1)
+	indices := make([]int, 0, len(sorted))
+	data := make([]float64, 0, len(sorted))
+
+	for i := 0; i < len(sorted); {
+		t := sorted[i]
+		v := t.Value
+
+		for i++; i < len(sorted) && major(sorted[i]) == major(t) && minor(sorted[i]) == minor(t); i++ {
+			v += sorted[i].Value
+		}
+
+		if v == 0 {
+			continue
+		}
+
+		indices = append(indices, minor(t))
+		data = append(data, v)
+		indptr[major(t)+1]++
+	}
+
+	for i := range n {
+		indptr[i+1] += indptr[i]
+	}
+
+	return indptr, indices, data, nil
+}
+
+func (m *Matrix) ToCSR() *CSR {
+	s := CSR{
+		Rows:   m.Rows,
+		Cols:   m.Cols,
+		Indptr: make([]int, m.Rows+1),
+	}
+
+	for i := range m.Rows {
+		for j, v := range m.Data[i*m.Cols : (i+1)*m.Cols] {
+			if v != 0 {
+				s.Indices = append(s.Indices, j)
+				s.Data = append(s.Data, v)
+			}
+		}
+
+		s.Indptr[i+1] = len(s.Data)
+	}
+
+	return &s
+}
+
+func (m *Matrix) ToCSC() *CSC {
+	return m.ToCSR().ToCSC()
+}
+
+func (s *CSR) NNZ() int {
+	return len(s.Data)
+}
+
+func (s *CSC) NNZ() int {
+	return len(s.Data)
+}
+
+func (s *CSR) At(row, col int) (float64, error) {
+	if row < 0 || row >= s.Rows || col < 0 || col >= s.Cols {
+		return 0, fmt.Errorf("index out of bounds [%d,%d] for matrix %dx%d",
+			row, col, s.Rows, s.Cols)
+	}
+
+	return lookup(s.Indices[s.Indptr[row]:s.Indptr[row+1]], s.Data[s.Indptr[row]:s.Indptr[row+1]], col), nil
+}
+
+func (s *CSC) At(row, col int) (float64, error) {
+	if row < 0 || row >= s.Rows || col < 0 || col >= s.Cols {
+		return 0, fmt.Errorf("index out of bounds [%d,%d] for matrix %dx%d",
+			row, col, s.Rows, s.Cols)
+	}
+
+	return lookup(s.Indices[s.Indptr[col]:s.Indptr[col+1]], s.Data[s.Indptr[col]:s.Indptr[col+1]], row), nil
+}
+
+func lookup(indices []int, data []float64, idx int) float64 {
+	p := sort.SearchInts(indices, idx)
+
+	if p < len(indices) && indices[p] == idx {
+		return data[p]
+	}
+
+	return 0
+}
+
+// Row returns the column indices and values of row i without copying.
+func (s *CSR) Row(i int) ([]int, []float64) {
+	return s.Indices[s.Indptr[i]:s.Indptr[i+1]], s.Data[s.Indptr[i]:s.Indptr[i+1]]
+}
+
+// Col returns the row indices and values of column j without copying.
+func (s *CSC) Col(j int) ([]int, []float64) {
+	return s.Indices[s.Indptr[j]:s.Indptr[j+1]], s.Data[s.Indptr[j]:s.Indptr[j+1]]
+}
+
+func (s *CSR) Dense() *Matrix {
+	m, err := NewMatrix(s.Rows, s.Cols)
+
+	if err != nil {
+		panic("creating a new matrix failed during Dense resulted in fatal error")
+	}
+
+	for i := range s.Rows {
+		for p := s.Indptr[i]; p < s.Indptr[i+1]; p++ {
+			m.Data[i*m.Cols+s.Indices[p]] = s.Data[p]
+		}
+	}
+
+	return m
+}
+
+func (s *CSC) Dense() *Matrix {
+	m, err := NewMatrix(s.Rows, s.Cols)
+
+	if err != nil {
+		panic("creating a new matrix failed during Dense resulted in fatal error")
+	}
+
+	for j := range s.Cols {
+		for p := s.Indptr[j]; p < s.Indptr[j+1]; p++ {
+			m.Data[s.Indices[p]*m.Cols+j] = s.Data[p]
+		}
+	}
+
+	return m
+}
+
+func (s *CSR) ToCSC() *CSC {
+	indptr, indices, data := transposeCompressed(s.Rows, s.Cols, s.Indptr, s.Indices, s.Data)
+
+	return &CSC{
+		Rows:    s.Rows,
+		Cols:    s.Cols,
+		Indptr:  indptr,
+		Indices: indices,
+		Data:    data,
+	}
+}
+
+func (s *CSC) ToCSR() *CSR {
+	indptr, indices, data := transposeCompressed(s.Cols, s.Rows, s.Indptr, s.Indices, s.Data)
+
+	return &CSR{
+		Rows:    s.Rows,
+		Cols:    s.Cols,
+		Indptr:  indptr,
+		Indices: indices,
+		Data:    data,
+	}
+}
+
+// transposeCompressed converts n major slices over m minor indices into m
+// major slices over n minor indices, keeping minor indices sorted.
+func transposeCompressed(n, m int, indptr, indices []int, data []float64) ([]int, []int, []float64) {
+	outPtr := make([]int, m+1)
+	outIdx := make([]int, len(indices))
+	outData := make([]float64, len(data))
+
+	for _, idx := range indices {
+		outPtr[idx+1]++
+	}
+
+	for i := range m {
+		outPtr[i+1] += outPtr[i]
+	}
+
+	next := make([]int, m)
+	copy(next, outPtr[:m])
+
+	for i := range n {
+		for p := indptr[i]; p < indptr[i+1]; p++ {
+			q := next[indices[p]]
+			outIdx[q] = i
+			outData[q] = data[p]
+			next[indices[p]]++
+		}
+	}
+
+	return outPtr, outIdx, outData
+}
+
+// Multiply returns the dense product s*m2.
+func (s *CSR) Multiply(m2 *Matrix) (*Matrix, error) {
+	result, err := NewMatrix(s.Rows, m2.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for multiply operation: %w", err)
+	}
+
+	err = s.MultiplyInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *CSR) MultiplyInto(dst, m2 *Matrix) error {
+	if s.Cols != m2.Rows {
+		return fmt.Errorf("cannot multiply: dimensions: %dx%d and %dx%d",
+			s.Rows, s.Cols, m2.Rows, m2.Cols)
+	}
+
+	err := checkDst("multiply", dst, s.Rows, m2.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	n := m2.Cols
+
+	for i := range s.Rows {
+		out := dst.Data[i*n : (i+1)*n]
+		clear(out)
+
+		for p := s.Indptr[i]; p < s.Indptr[i+1]; p++ {
+			v := s.Data[p]
+			row := m2.Data[s.Indices[p]*n : (s.Indices[p]+1)*n]
+
+			for j, b := range row {
+				out[j] += v * b
+			}
+		}
+	}
+
+	return nil
+}
+
+// Multiply returns the dense product s*m2.
+func (s *CSC) Multiply(m2 *Matrix) (*Matrix, error) {
+	result, err := NewMatrix(s.Rows, m2.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new matrix for multiply operation: %w", err)
+	}
+
+	err = s.MultiplyInto(result, m2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *CSC) MultiplyInto(dst, m2 *Matrix) error {
+	if s.Cols != m2.Rows {
+		return fmt.Errorf("cannot multiply: dimensions: %dx%d and %dx%d",
+			s.Rows, s.Cols, m2.Rows, m2.Cols)
+	}
+
+	err := checkDst("multiply", dst, s.Rows, m2.Cols)
+
+	if err != nil {
+		return err
+	}
+
+	n := m2.Cols
+	clear(dst.Data)
+
+	for k := range s.Cols {
+		row := m2.Data[k*n : (k+1)*n]
+
+		for p := s.Indptr[k]; p < s.Indptr[k+1]; p++ {
+			v := s.Data[p]
+			out := dst.Data[s.Indices[p]*n : (s.Indices[p]+1)*n]
+
+			for j, b := range row {
+				out[j] += v * b
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package matrix
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestNewCSR(t *testing.T) {
+	// Output M
+	// [1 0 2]
+	// [0 0 0]
+	// [0 3 0]
+
+	s, err := NewCSR(3, 3, []Triplet{
+		{Row: 2, Col: 1, Value: 3},
+		{Row: 0, Col: 2, Value: 1},
+		{Row: 0, Col: 0, Value: 1},
+		{Row: 0, Col: 2, Value: 1},
+		{Row: 1, Col: 1, Value: 0},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if s.NNZ() != 3 {
+		t.Errorf("expected %d non-zeros, got %d", 3, s.NNZ())
+	}
+
+	assertData(t, s.Dense(), []float64{1, 0, 2, 0, 0, 0, 0, 3, 0})
+
+	if v, _ := s.At(0, 2); v != 2 {
+		t.Errorf("expected 0x2 to be %f, got %f", float64(2), v)
+	}
+
+	if v, _ := s.At(1, 1); v != 0 {
+		t.Errorf("expected 1x1 to be %f, got %f", float64(0), v)
+	}
+}
+
+func TestNewCSRError(t *testing.T) {
+	_, err := NewCSR(2, 2, []Triplet{{Row: 2, Col: 0, Value: 1}})
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestSparseConversions(t *testing.T) {
+	m := newTestMatrix(2, 3, 0, 4, 0, 5, 0, 6)
+
+	csr := m.ToCSR()
+	csc := m.ToCSC()
+
+	assertData(t, csr.Dense(), m.Data)
+	assertData(t, csc.Dense(), m.Data)
+	assertData(t, csr.ToCSC().Dense(), m.Data)
+	assertData(t, csc.ToCSR().Dense(), m.Data)
+
+	if v, _ := csc.At(1, 2); v != 6 {
+		t.Errorf("expected 1x2 to be %f, got %f", float64(6), v)
+	}
+
+	indices, values := csr.Row(1)
+
+	if len(indices) != 2 || indices[0] != 0 || values[1] != 6 {
+		t.Errorf("expected row 1 indices [0 2] values [5 6], got %v %v", indices, values)
+	}
+}
+
+func TestSparseMultiply(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 8))
+	a := randomMatrix(r, 20, 30)
+
+	for i := range a.Data {
+		if r.Float64() < 0.9 {
+			a.Data[i] = 0
+		}
+	}
+
+	b := randomMatrix(r, 30, 7)
+	exp := naiveMultiply(a, b)
+
+	m, err := a.ToCSR().Multiply(b)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertClose(t, m, exp.Data, 1e-12)
+
+	m, err = a.ToCSC().Multiply(b)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertClose(t, m, exp.Data, 1e-12)
+
+	_, err = a.ToCSR().Multiply(a)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -0,0 +1,156 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SVD holds the thin singular value decomposition A = U*diag(Values)*V^T
+// of an m x n matrix, with k = min(m, n) singular values sorted in
+// descending order. U is m x k and V is n x k.
+type SVD struct {
+	U      *Matrix
+	Values []float64
+	V      *Matrix
+}
+
+// SVD decomposes m with the one-sided Jacobi (Hestenes) method.
+func (m *Matrix) SVD() (*SVD, error) {
+	if m.Rows < m.Cols {
+		t, err := m.Transpose().SVD()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &SVD{
+			U:      t.V,
+			Values: t.Values,
+			V:      t.U,
+		}, nil
+	}
+
+	u := m.Clone()
+	n := m.Cols
+	v := Identity(n)
+	converged := false
+
+	for range maxSweeps {
+		rotated := false
+
+		for p := range n {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := colProducts(u, p, q)
+
+				if gamma == 0 || math.Abs(gamma) <= epsilon*math.Sqrt(alpha*beta) {
+					continue
+				}
+
+				rotated = true
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Hypot(zeta, 1))
+				c := 1 / math.Hypot(t, 1)
+				s := c * t
+
+				rotateCols(u, p, q, c, s)
+				rotateCols(v, p, q, c, s)
+			}
+		}
+
+		if !rotated {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return nil, fmt.Errorf("failed to compute SVD: %w", ErrNoConvergence)
+	}
+
+	values := make([]float64, n)
+
+	for j := range n {
+		nrm := float64(0)
+
+		for i := range u.Rows {
+			nrm = math.Hypot(nrm, u.Data[i*n+j])
+		}
+
+		values[j] = nrm
+
+		if nrm == 0 {
+			continue
+		}
+
+		for i := range u.Rows {
+			u.Data[i*n+j] /= nrm
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	s := SVD{
+		U:      permuteCols(u, order),
+		Values: make([]float64, n),
+		V:      permuteCols(v, order),
+	}
+
+	for i, o := range order {
+		s.Values[i] = values[o]
+	}
+
+	return &s, nil
+}
+
+// Cond returns the 2-norm condition number, +Inf for singular input.
+func (s *SVD) Cond() float64 {
+	if len(s.Values) == 0 {
+		return 0
+	}
+
+	smallest := s.Values[len(s.Values)-1]
+
+	if smallest == 0 {
+		return math.Inf(1)
+	}
+
+	return s.Values[0] / smallest
+}
+
+// Rank counts singular values above a tolerance relative to the largest.
+func (s *SVD) Rank() int {
+	if len(s.Values) == 0 {
+		return 0
+	}
+
+	tol := float64(max(s.U.Rows, s.V.Rows)) * epsilon * s.Values[0]
+	rank := 0
+
+	for _, v := range s.Values {
+		if v > tol {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+func colProducts(m *Matrix, p, q int) (alpha, beta, gamma float64) {
+	for i := range m.Rows {
+		mp := m.Data[i*m.Cols+p]
+		mq := m.Data[i*m.Cols+q]
+		alpha += mp * mp
+		beta += mq * mq
+		gamma += mp * mq
+	}
+
+	return alpha, beta, gamma
+}
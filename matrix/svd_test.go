@@ -0,0 +1,76 @@
+package matrix
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func reconstructSVD(s *SVD) *Matrix {
+	us := s.U.Clone()
+
+	for i := range us.Rows {
+		for j := range us.Cols {
+			us.Data[i*us.Cols+j] *= s.Values[j]
+		}
+	}
+
+	m, _ := us.Multiply(s.V.Transpose())
+
+	return m
+}
+
+func TestSVD(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	for _, dims := range [][2]int{{5, 3}, {3, 5}, {4, 4}} {
+		a := randomMatrix(r, dims[0], dims[1])
+
+		s, err := a.SVD()
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		k := min(dims[0], dims[1])
+
+		if len(s.Values) != k || s.U.Cols != k || s.V.Cols != k {
+			t.Fatalf("expected %d singular values and vectors, got %d, %d, %d",
+				k, len(s.Values), s.U.Cols, s.V.Cols)
+		}
+
+		assertClose(t, reconstructSVD(s), a.Data, 1e-9)
+
+		utu, _ := s.U.Transpose().Multiply(s.U)
+
+		assertClose(t, utu, Identity(k).Data, 1e-9)
+
+		for i := 1; i < k; i++ {
+			if s.Values[i] > s.Values[i-1] {
+				t.Errorf("expected singular values in descending order, got %v", s.Values)
+			}
+		}
+	}
+}
+
+func TestSVDRankAndCond(t *testing.T) {
+	a := newTestMatrix(3, 2, 1, 2, 2, 4, 3, 6)
+
+	s, err := a.SVD()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if s.Rank() != 1 {
+		t.Errorf("expected rank %d, got %d", 1, s.Rank())
+	}
+
+	if math.Abs(s.Values[0]-math.Sqrt(70)) > 1e-9 {
+		t.Errorf("expected largest singular value %f, got %f", math.Sqrt(70), s.Values[0])
+	}
+
+	if s.Cond() < 1e12 {
+		t.Errorf("expected ill-conditioned matrix, got condition number %f", s.Cond())
+	}
+}
@@ -0,0 +1,178 @@
+package matrix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// View is a rectangular window onto another matrix's Data. Element (i, j)
+// lives at Data[i*Stride+j], so writes through a View are visible in the
+// matrix it was taken from.
+type View struct {
+	Rows   int
+	Cols   int
+	Stride int
+	Data   []float64
+}
+
+// RowView returns row of m as a slice sharing m.Data.
+func (m *Matrix) RowView(row int) ([]float64, error) {
+	if row < 0 || row >= m.Rows {
+		return nil, fmt.Errorf("row index %d out of bounds for matrix %dx%d",
+			row, m.Rows, m.Cols)
+	}
+
+	return m.Data[row*m.Cols : (row+1)*m.Cols : (row+1)*m.Cols], nil
+}
+
+// RowRange returns rows [start, end) of m as a Matrix sharing m.Data.
+func (m *Matrix) RowRange(start, end int) (*Matrix, error) {
+	if start < 0 || end > m.Rows || start > end {
+		return nil, fmt.Errorf("row range [%d,%d) out of bounds for matrix %dx%d",
+			start, end, m.Rows, m.Cols)
+	}
+
+	return &Matrix{
+		Rows: end - start,
+		Cols: m.Cols,
+		Data: m.Data[start*m.Cols : end*m.Cols : end*m.Cols],
+	}, nil
+}
+
+// ColRange returns columns [start, end) of m as a View.
+func (m *Matrix) ColRange(start, end int) (*View, error) {
+	return m.View(0, start, m.Rows, end-start)
+}
+
+// View returns the rows x cols window of m whose top-left corner is
+// (row, col).
+func (m *Matrix) View(row, col, rows, cols int) (*View, error) {
+	if row < 0 || col < 0 || rows < 0 || cols < 0 || row+rows > m.Rows || col+cols > m.Cols {
+		return nil, fmt.Errorf("view [%d:%d,%d:%d] out of bounds for matrix %dx%d",
+			row, row+rows, col, col+cols, m.Rows, m.Cols)
+	}
+
+	v := View{
+		Rows:   rows,
+		Cols:   cols,
+		Stride: m.Cols,
+	}
+
+	if rows > 0 && cols > 0 {
+		start := row*m.Cols + col
+		end := (row+rows-1)*m.Cols + col + cols
+		v.Data = m.Data[start:end:end]
+	}
+
+	return &v, nil
+}
+
+// AtUnchecked returns the element at (row, col) without bounds checking
+// the matrix dimensions. Intended for hot loops with known-valid indices.
+func (m *Matrix) AtUnchecked(row, col int) float64 {
+	return m.Data[row*m.Cols+col]
+}
+
+// SetUnchecked sets the element at (row, col) without bounds checking the
+// matrix dimensions.
+func (m *Matrix) SetUnchecked(row, col int, s float64) {
+	m.Data[row*m.Cols+col] = s
+}
+
+func (v *View) At(row, col int) (float64, error) {
+	if !v.validIndex(row, col) {
+		return 0, fmt.Errorf("index out of bounds [%d,%d] for view %dx%d",
+			row, col, v.Rows, v.Cols)
+	}
+
+	return v.Data[row*v.Stride+col], nil
+}
+
+func (v *View) Set(row, col int, s float64) error {
+	if !v.validIndex(row, col) {
+		return fmt.Errorf("index out of bounds [%d,%d] for view %dx%d",
+			row, col, v.Rows, v.Cols)
+	}
+
+	v.Data[row*v.Stride+col] = s
+
+	return nil
+}
+
+func (v *View) AtUnchecked(row, col int) float64 {
+	return v.Data[row*v.Stride+col]
+}
+
+func (v *View) SetUnchecked(row, col int, s float64) {
+	v.Data[row*v.Stride+col] = s
+}
+
+func (v *View) validIndex(row, col int) bool {
+	return row >= 0 && row < v.Rows && col >= 0 && col < v.Cols
+}
+
+// Row returns row of the view as a slice sharing the underlying Data.
+func (v *View) Row(row int) ([]float64, error) {
+	if row < 0 || row >= v.Rows {
+		return nil, fmt.Errorf("row index %d out of bounds for view %dx%d",
+			row, v.Rows, v.Cols)
+	}
+
+	start := row * v.Stride
+
+	return v.Data[start : start+v.Cols : start+v.Cols], nil
+}
+
+// View returns a window into v, sharing the same Data.
+func (v *View) View(row, col, rows, cols int) (*View, error) {
+	if row < 0 || col < 0 || rows < 0 || cols < 0 || row+rows > v.Rows || col+cols > v.Cols {
+		return nil, fmt.Errorf("view [%d:%d,%d:%d] out of bounds for view %dx%d",
+			row, row+rows, col, col+cols, v.Rows, v.Cols)
+	}
+
+	sub := View{
+		Rows:   rows,
+		Cols:   cols,
+		Stride: v.Stride,
+	}
+
+	if rows > 0 && cols > 0 {
+		start := row*v.Stride + col
+		end := (row+rows-1)*v.Stride + col + cols
+		sub.Data = v.Data[start:end:end]
+	}
+
+	return &sub, nil
+}
+
+// Matrix copies the view into a new contiguous Matrix.
+func (v *View) Matrix() *Matrix {
+	m, err := NewMatrix(v.Rows, v.Cols)
+
+	if err != nil {
+		panic("creating a new matrix failed during View.Matrix resulted in fatal error")
+	}
+
+	for i := range v.Rows {
+		copy(m.Data[i*v.Cols:(i+1)*v.Cols], v.Data[i*v.Stride:i*v.Stride+v.Cols])
+	}
+
+	return m
+}
+
+func (v *View) String() string {
+	var builder strings.Builder
+
+	for i := range v.Rows {
+		builder.WriteString("[")
+		for j := range v.Cols {
+			if j > 0 {
+				builder.WriteString(" ")
+			}
+			builder.WriteString(fmt.Sprintf("%.2f", v.Data[i*v.Stride+j]))
+		}
+		builder.WriteString("]\n")
+	}
+
+	return builder.String()
+}
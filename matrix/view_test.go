@@ -0,0 +1,120 @@
+package matrix
+
+import (
+	"testing"
+)
+
+func TestRowView(t *testing.T) {
+	m := newTestMatrix(2, 3, 2, 3, 4, 5, 6, 7)
+
+	row, err := m.RowView(1)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	row[0] = 9
+
+	if v, _ := m.At(1, 0); v != 9 {
+		t.Errorf("expected write through row view, got %f", v)
+	}
+
+	_ = append(row, 1)
+
+	if len(m.Data) != 6 {
+		t.Errorf("expected append on row view to not grow matrix, got %d", len(m.Data))
+	}
+
+	_, err = m.RowView(2)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestRowRange(t *testing.T) {
+	m := newTestMatrix(3, 2, 1, 2, 3, 4, 5, 6)
+
+	r, err := m.RowRange(1, 3)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, r, []float64{3, 4, 5, 6})
+
+	_ = r.Set(0, 0, 8)
+
+	if v, _ := m.At(1, 0); v != 8 {
+		t.Errorf("expected write through row range, got %f", v)
+	}
+
+	_, err = m.RowRange(2, 4)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestView(t *testing.T) {
+	// Input M
+	// [1 2 3 4]
+	// [5 6 7 8]
+	// [9 10 11 12]
+
+	m := newTestMatrix(3, 4, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)
+
+	v, err := m.View(1, 1, 2, 2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, v.Matrix(), []float64{6, 7, 10, 11})
+
+	err = v.Set(1, 1, 0)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if s, _ := m.At(2, 2); s != 0 {
+		t.Errorf("expected write through view, got %f", s)
+	}
+
+	row, _ := v.Row(0)
+
+	if len(row) != 2 || row[1] != 7 {
+		t.Errorf("expected view row [6 7], got %v", row)
+	}
+
+	sub, err := v.View(0, 1, 2, 1)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, sub.Matrix(), []float64{7, 0})
+
+	_, err = m.View(2, 2, 2, 2)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestColRange(t *testing.T) {
+	m := newTestMatrix(2, 3, 2, 3, 4, 5, 6, 7)
+
+	v, err := m.ColRange(1, 3)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertData(t, v.Matrix(), []float64{3, 4, 6, 7})
+
+	if s := v.AtUnchecked(1, 0); s != 6 {
+		t.Errorf("expected 1x0 to be %f, got %f", float64(6), s)
+	}
+}
@@ -1,14 +1,36 @@
 package activation
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 type Activation struct {
+	Name    string
 	Fn      func(z float64) float64
 	FnPrime func(z float64) float64
 }
 
+// ByName returns the built-in activation with the given Name, used when
+// restoring saved models.
+func ByName(name string) (*Activation, error) {
+	switch name {
+	case "identity":
+		return Identity(), nil
+	case "identity_round":
+		return IdentityRound(), nil
+	case "relu":
+		return ReLU(), nil
+	case "sigmoid":
+		return Sigmoid(), nil
+	default:
+		return nil, fmt.Errorf("unknown activation: %s", name)
+	}
+}
+
 func IdentityRound() *Activation {
 	return &Activation{
+		Name:    "identity_round",
 		Fn:      identityRound,
 		FnPrime: identityPrime,
 	}
@@ -17,6 +39,7 @@ func IdentityRound() *Activation {
 
 func Identity() *Activation {
 	return &Activation{
+		Name:    "identity",
 		Fn:      identity,
 		FnPrime: identityPrime,
 	}
@@ -36,6 +59,7 @@ func identityPrime(_ float64) float64 {
 
 func ReLU() *Activation {
 	return &Activation{
+		Name:    "relu",
 		Fn:      relu,
 		FnPrime: reluPrime,
 	}
@@ -54,6 +78,7 @@ func reluPrime(z float64) float64 {
 
 func Sigmoid() *Activation {
 	return &Activation{
+		Name:    "sigmoid",
 		Fn:      sigmoid,
 		FnPrime: sigmoidPrime,
 	}
@@ -0,0 +1,303 @@
+package neuralnet
+
+import (
+	"errors"
+	"fmt"
+	"gonn/datatable"
+	"gonn/matrix"
+	"gonn/vector"
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+type CVMethod int
+
+const (
+	KFold CVMethod = iota
+	StratifiedKFold
+	LeaveOneOut
+)
+
+// FitOptions selects the feature and target columns a model is trained on
+// and how many passes it makes over the training rows.
+type FitOptions struct {
+	Features []string
+	Targets  []string
+	Epochs   int
+}
+
+// CVOptions configures CrossValidate. Repeats reruns KFold or
+// StratifiedKFold with a different shuffle each time; Label is the column
+// StratifiedKFold keeps balanced. Parallel is the number of folds trained
+// at once, with 0 or 1 running them one after another.
+type CVOptions struct {
+	Method   CVMethod
+	Folds    int
+	Repeats  int
+	Label    string
+	Seed     uint64
+	Parallel int
+	Metrics  []Metric
+}
+
+type FoldResult struct {
+	Repeat    int
+	Fold      int
+	TrainRows int
+	TestRows  int
+	Scores    map[string]float64
+}
+
+type CVResult struct {
+	Folds []FoldResult
+	Mean  map[string]float64
+	Std   map[string]float64
+}
+
+// CrossValidate trains a fresh model from factory on each fold of d and
+// scores it on the held out rows. Training rows are visited in a seeded
+// random order every epoch, so results are reproducible for a given Seed.
+func CrossValidate(d *datatable.DataTable, factory func() (*NeuralNet, error), fit FitOptions, opts CVOptions) (*CVResult, error) {
+	if len(opts.Metrics) == 0 {
+		return nil, errors.New("cross validation requires at least one metric")
+	}
+
+	if len(fit.Features) == 0 || len(fit.Targets) == 0 {
+		return nil, errors.New("cross validation requires feature and target columns")
+	}
+
+	x, err := d.ToMatrix(fit.Features...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cross validation features: %w", err)
+	}
+
+	y, err := d.ToMatrix(fit.Targets...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cross validation targets: %w", err)
+	}
+
+	folds, err := cvFolds(d, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FoldResult, len(folds))
+	errs := make([]error, len(folds))
+	sem := make(chan struct{}, max(1, opts.Parallel))
+
+	var wg sync.WaitGroup
+
+	// Models are created as their fold starts, so only the folds in flight
+	// hold one
+	for i, f := range folds {
+		sem <- struct{}{}
+
+		nn, err := factory()
+
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to create model: %w", err)
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i], errs[i] = runFold(nn, x, y, f, fit.Epochs, opts.Seed+uint64(i), opts.Metrics)
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed cross validation fold %d: %w", i, err)
+		}
+	}
+
+	result := CVResult{
+		Folds: results,
+		Mean:  make(map[string]float64),
+		Std:   make(map[string]float64),
+	}
+
+	scores := make([]float64, len(results))
+
+	for _, metric := range opts.Metrics {
+		for i, r := range results {
+			scores[i] = r.Scores[metric.Name]
+		}
+
+		result.Mean[metric.Name], _ = vector.Mean(scores)
+		variance, _ := vector.Variance(scores)
+		result.Std[metric.Name] = math.Sqrt(variance)
+	}
+
+	return &result, nil
+}
+
+type fold struct {
+	repeat int
+	index  int
+	train  []int
+	test   []int
+}
+
+func runFold(nn *NeuralNet, x, y *matrix.Matrix, f fold, epochs int, seed uint64, metrics []Metric) (FoldResult, error) {
+	r := rand.New(rand.NewPCG(seed, 0))
+	order := make([]int, len(f.train))
+	copy(order, f.train)
+
+	for range max(1, epochs) {
+		r.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+
+		for _, row := range order {
+			err := nn.Train(x.Data[row*x.Cols:(row+1)*x.Cols], y.Data[row*y.Cols:(row+1)*y.Cols])
+
+			if err != nil {
+				return FoldResult{}, err
+			}
+		}
+	}
+
+	yTest, _ := matrix.NewMatrix(len(f.test), y.Cols)
+	yPred, _ := matrix.NewMatrix(len(f.test), y.Cols)
+
+	for i, row := range f.test {
+		copy(yTest.Data[i*y.Cols:(i+1)*y.Cols], y.Data[row*y.Cols:(row+1)*y.Cols])
+
+		pred, err := nn.Predict(x.Data[row*x.Cols : (row+1)*x.Cols])
+
+		if err != nil {
+			return FoldResult{}, err
+		}
+
+		copy(yPred.Data[i*y.Cols:(i+1)*y.Cols], pred)
+	}
+
+	scores := make(map[string]float64, len(metrics))
+
+	for _, metric := range metrics {
+		scores[metric.Name] = metric.Fn(yTest, yPred)
+	}
+
+	return FoldResult{
+		Repeat:    f.repeat,
+		Fold:      f.index,
+		TrainRows: len(f.train),
+		TestRows:  len(f.test),
+		Scores:    scores,
+	}, nil
+}
+
+// cvFolds assigns every row to one test fold per repeat.
+func cvFolds(d *datatable.DataTable, opts CVOptions) ([]fold, error) {
+	n := d.Matrix.Rows
+	k := opts.Folds
+	repeats := max(1, opts.Repeats)
+
+	var labels []float64
+
+	switch opts.Method {
+	case KFold:
+	case StratifiedKFold:
+		l, err := d.Select(opts.Label)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stratification label: %w", err)
+		}
+
+		labels = l.Matrix.Data
+	case LeaveOneOut:
+		k = n
+		repeats = 1
+	default:
+		return nil, fmt.Errorf("unknown cross validation method %d", opts.Method)
+	}
+
+	if k < 2 || k > n {
+		return nil, fmt.Errorf("cross validation requires 2 to %d folds, got %d", n, k)
+	}
+
+	folds := make([]fold, 0, k*repeats)
+
+	for rep := range repeats {
+		r := rand.New(rand.NewPCG(opts.Seed, uint64(rep)))
+		assign := make([]int, n)
+
+		switch {
+		case opts.Method == LeaveOneOut:
+			for i := range assign {
+				assign[i] = i
+			}
+		case labels != nil:
+			// Deal each shuffled class round-robin, continuing where the
+			// previous class stopped so fold sizes stay even
+			classes := make(map[uint64][]int)
+			order := make([]uint64, 0)
+
+			for row, v := range labels {
+				key := labelKey(v)
+
+				if _, ok := classes[key]; !ok {
+					order = append(order, key)
+				}
+
+				classes[key] = append(classes[key], row)
+			}
+
+			next := 0
+
+			for _, key := range order {
+				rows := classes[key]
+				r.Shuffle(len(rows), func(i, j int) {
+					rows[i], rows[j] = rows[j], rows[i]
+				})
+
+				for _, row := range rows {
+					assign[row] = next % k
+					next++
+				}
+			}
+		default:
+			for i, row := range r.Perm(n) {
+				assign[row] = i * k / n
+			}
+		}
+
+		for f := range k {
+			fd := fold{repeat: rep, index: f}
+
+			for row, a := range assign {
+				if a == f {
+					fd.test = append(fd.test, row)
+				} else {
+					fd.train = append(fd.train, row)
+				}
+			}
+
+			folds = append(folds, fd)
+		}
+	}
+
+	return folds, nil
+}
+
+// labelKey returns the class a stratification label falls in. Missing
+// labels form one class, and negative and positive zero are the same.
+func labelKey(v float64) uint64 {
+	if math.IsNaN(v) {
+		return math.Float64bits(datatable.NA())
+	}
+
+	return math.Float64bits(v + 0)
+}
@@ -0,0 +1,231 @@
+package neuralnet
+
+import (
+	"errors"
+	"gonn/datatable"
+	"gonn/neuralnet/activation"
+	"gonn/neuralnet/loss"
+	"math"
+	"testing"
+)
+
+// cvRows are 30 rows of x, y = 0.5x and a class label that is 1 for one
+// row in three.
+var cvRows = [][]float64{
+	{0.0 / 30, 0.0 / 60, 1},
+	{1.0 / 30, 1.0 / 60, 0},
+	{2.0 / 30, 2.0 / 60, 0},
+	{3.0 / 30, 3.0 / 60, 1},
+	{4.0 / 30, 4.0 / 60, 0},
+	{5.0 / 30, 5.0 / 60, 0},
+	{6.0 / 30, 6.0 / 60, 1},
+	{7.0 / 30, 7.0 / 60, 0},
+	{8.0 / 30, 8.0 / 60, 0},
+	{9.0 / 30, 9.0 / 60, 1},
+	{10.0 / 30, 10.0 / 60, 0},
+	{11.0 / 30, 11.0 / 60, 0},
+	{12.0 / 30, 12.0 / 60, 1},
+	{13.0 / 30, 13.0 / 60, 0},
+	{14.0 / 30, 14.0 / 60, 0},
+	{15.0 / 30, 15.0 / 60, 1},
+	{16.0 / 30, 16.0 / 60, 0},
+	{17.0 / 30, 17.0 / 60, 0},
+	{18.0 / 30, 18.0 / 60, 1},
+	{19.0 / 30, 19.0 / 60, 0},
+	{20.0 / 30, 20.0 / 60, 0},
+	{21.0 / 30, 21.0 / 60, 1},
+	{22.0 / 30, 22.0 / 60, 0},
+	{23.0 / 30, 23.0 / 60, 0},
+	{24.0 / 30, 24.0 / 60, 1},
+	{25.0 / 30, 25.0 / 60, 0},
+	{26.0 / 30, 26.0 / 60, 0},
+	{27.0 / 30, 27.0 / 60, 1},
+	{28.0 / 30, 28.0 / 60, 0},
+	{29.0 / 30, 29.0 / 60, 0},
+}
+
+func newCVNet() (*NeuralNet, error) {
+	nn := NewNeuralNet(0.05, loss.MSE)
+
+	err := nn.AddInputLayer(1)
+	if err != nil {
+		return nil, err
+	}
+	err = nn.AddHiddenLayer(4, activation.Sigmoid())
+	if err != nil {
+		return nil, err
+	}
+	err = nn.AddOutputLayer(1, activation.Identity())
+	if err != nil {
+		return nil, err
+	}
+
+	return nn, nil
+}
+
+func TestCrossValidate(t *testing.T) {
+	d := datatable.NewDataTable([]string{"x", "y", "class"})
+
+	for _, r := range cvRows {
+		_ = d.AddRow(r)
+	}
+
+	fit := FitOptions{Features: []string{"x"}, Targets: []string{"y"}, Epochs: 20}
+
+	res, err := CrossValidate(d, newCVNet, fit, CVOptions{
+		Method:   KFold,
+		Folds:    5,
+		Repeats:  2,
+		Seed:     1,
+		Parallel: 4,
+		Metrics:  []Metric{MeanSquaredError(), MeanAbsoluteError()},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(res.Folds) != 10 {
+		t.Fatalf("expected %d folds, got %d", 10, len(res.Folds))
+	}
+
+	for _, f := range res.Folds {
+		if f.TrainRows != 24 || f.TestRows != 6 {
+			t.Errorf("expected 24/6 rows in fold %d, got %d/%d", f.Fold, f.TrainRows, f.TestRows)
+		}
+	}
+
+	mse := res.Mean["mse"]
+
+	if math.IsNaN(mse) || mse > 0.1 {
+		t.Errorf("expected a small mean mse, got %v", mse)
+	}
+
+	if _, ok := res.Std["mae"]; !ok {
+		t.Errorf("expected a mae standard deviation")
+	}
+}
+
+func TestCVFolds(t *testing.T) {
+	d := datatable.NewDataTable([]string{"x", "y", "class"})
+
+	for _, r := range cvRows {
+		_ = d.AddRow(r)
+	}
+
+	folds, err := cvFolds(d, CVOptions{Method: StratifiedKFold, Folds: 5, Label: "class", Seed: 3})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	seen := make(map[int]bool)
+
+	for _, f := range folds {
+		positives := 0
+
+		for _, row := range f.test {
+			if row%3 == 0 {
+				positives++
+			}
+
+			if seen[row] {
+				t.Errorf("expected row %d in only one test fold", row)
+			}
+
+			seen[row] = true
+		}
+
+		if positives != 2 {
+			t.Errorf("expected %d positive rows in fold %d, got %d", 2, f.index, positives)
+		}
+	}
+
+	if len(seen) != 30 {
+		t.Errorf("expected every row to be tested once, got %d", len(seen))
+	}
+
+	folds, err = cvFolds(d, CVOptions{Method: LeaveOneOut})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(folds) != 30 || len(folds[7].test) != 1 || folds[7].test[0] != 7 {
+		t.Errorf("expected one row per leave-one-out fold")
+	}
+
+	_, err = cvFolds(d, CVOptions{Method: KFold, Folds: 1})
+
+	if err == nil {
+		t.Errorf("expected error for a single fold, got nil")
+	}
+}
+
+func TestCVFoldsMissingLabels(t *testing.T) {
+	d := datatable.NewDataTable([]string{"x", "y", "class"})
+
+	for _, r := range cvRows {
+		_ = d.AddRow(r)
+	}
+
+	for row := 0; row < 30; row += 3 {
+		_ = d.Matrix.Set(row, 2, math.NaN())
+	}
+
+	folds, err := cvFolds(d, CVOptions{Method: StratifiedKFold, Folds: 5, Label: "class", Seed: 3})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, f := range folds {
+		missing := 0
+
+		for _, row := range f.test {
+			if row%3 == 0 {
+				missing++
+			}
+		}
+
+		if len(f.test) != 6 || missing != 2 {
+			t.Errorf("expected 6 rows with %d missing labels in fold %d, got %d with %d",
+				2, f.index, len(f.test), missing)
+		}
+	}
+}
+
+func TestCrossValidateFactoryError(t *testing.T) {
+	d := datatable.NewDataTable([]string{"x", "y", "class"})
+
+	for _, r := range cvRows {
+		_ = d.AddRow(r)
+	}
+
+	fit := FitOptions{Features: []string{"x"}, Targets: []string{"y"}, Epochs: 1}
+	calls := 0
+
+	factory := func() (*NeuralNet, error) {
+		calls++
+
+		if calls == 3 {
+			return nil, errors.New("out of models")
+		}
+
+		return newCVNet()
+	}
+
+	_, err := CrossValidate(d, factory, fit, CVOptions{
+		Method:  KFold,
+		Folds:   5,
+		Metrics: []Metric{MeanSquaredError()},
+	})
+
+	if err == nil {
+		t.Fatal("expected factory error, got nil")
+	}
+
+	if calls != 3 {
+		t.Errorf("expected the factory to stop after %d calls, got %d", 3, calls)
+	}
+}
@@ -6,29 +6,31 @@ import (
 	"gonn/neuralnet/activation"
 )
 
-type layer struct {
+// layer holds its values in T. Weights is row-major, with one row of Units
+// weights per unit of NextLayer.
+type layer[T matrix.Float] struct {
 	Units         int
-	Values        []float64
-	ZValues       []float64
-	Weights       *matrix.Matrix
-	Biases        []float64
-	Gradients     []float64
-	NextLayer     *layer
+	Values        []T
+	ZValues       []T
+	Weights       []T
+	Biases        []T
+	Gradients     []T
+	NextLayer     *layer[T]
 	Activation    *activation.Activation
 	IsInputLayer  bool
 	IsOutputLayer bool
 }
 
-func newLayer(units int, activation *activation.Activation, isInputLayer bool, isOutputLayer bool) (*layer, error) {
+func newLayer[T matrix.Float](units int, activation *activation.Activation, isInputLayer bool, isOutputLayer bool) (*layer[T], error) {
 	if units < 1 {
 		return nil, fmt.Errorf("layer units must be greater than 0, got %d", units)
 	}
 
-	layer := layer{
+	layer := layer[T]{
 		Units:         units,
-		Values:        make([]float64, units),
-		ZValues:       make([]float64, units),
-		Gradients:     make([]float64, units),
+		Values:        make([]T, units),
+		ZValues:       make([]T, units),
+		Gradients:     make([]T, units),
 		Activation:    activation,
 		IsInputLayer:  isInputLayer,
 		IsOutputLayer: isOutputLayer,
@@ -36,3 +38,8 @@ func newLayer(units int, activation *activation.Activation, isInputLayer bool, i
 
 	return &layer, nil
 }
+
+// weightRow returns the weights from this layer into unit of the next.
+func (l *layer[T]) weightRow(unit int) []T {
+	return l.Weights[unit*l.Units : (unit+1)*l.Units : (unit+1)*l.Units]
+}
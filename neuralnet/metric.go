@@ -0,0 +1,78 @@
+package neuralnet
+
+import (
+	"gonn/matrix"
+	"gonn/vector"
+	"math"
+)
+
+// Metric scores predictions against targets, one row per sample.
+type Metric struct {
+	Name string
+	Fn   func(y, yPred *matrix.Matrix) float64
+}
+
+func MeanSquaredError() Metric {
+	return Metric{
+		Name: "mse",
+		Fn: func(y, yPred *matrix.Matrix) float64 {
+			sum := 0.0
+
+			for i, v := range y.Data {
+				d := v - yPred.Data[i]
+				sum += d * d
+			}
+
+			return sum / float64(len(y.Data))
+		},
+	}
+}
+
+func MeanAbsoluteError() Metric {
+	return Metric{
+		Name: "mae",
+		Fn: func(y, yPred *matrix.Matrix) float64 {
+			sum := 0.0
+
+			for i, v := range y.Data {
+				sum += math.Abs(v - yPred.Data[i])
+			}
+
+			return sum / float64(len(y.Data))
+		},
+	}
+}
+
+// Accuracy is the fraction of rows predicted correctly. Single output
+// models are compared after rounding; multiple outputs by their largest
+// value, as with one-hot targets.
+func Accuracy() Metric {
+	return Metric{
+		Name: "accuracy",
+		Fn: func(y, yPred *matrix.Matrix) float64 {
+			correct := 0
+
+			for row := range y.Rows {
+				t := y.Data[row*y.Cols : (row+1)*y.Cols]
+				p := yPred.Data[row*y.Cols : (row+1)*y.Cols]
+
+				if y.Cols == 1 {
+					if math.Round(p[0]) == t[0] {
+						correct++
+					}
+
+					continue
+				}
+
+				ti, _ := vector.ArgMax(t)
+				pi, _ := vector.ArgMax(p)
+
+				if ti == pi {
+					correct++
+				}
+			}
+
+			return float64(correct) / float64(y.Rows)
+		},
+	}
+}
@@ -13,85 +13,207 @@ import (
 type NeuralNet struct {
 	LossFn       func(y, yPred float64) float64
 	LearningRate float64
-	layers       []*layer
+	net          network
 	hasTrained   bool
 }
 
+// network is the layer stack of a NeuralNet in its compute precision. The
+// exported methods take and return float64 values and convert at the
+// boundary.
+type network interface {
+	precision() Precision
+	size() int
+	flags(i int) (isInput, isOutput bool)
+	addLayer(units int, activation *activation.Activation, isInputLayer, isOutputLayer bool) error
+	train(x, y []float64, lr float64) error
+	predict(x []float64) ([]float64, error)
+	trainSparse(x *matrix.CSR, y *matrix.Matrix, lr float64) error
+	predictSparse(x *matrix.CSR) (*matrix.Matrix, error)
+	encode(enc *encoder) error
+}
+
+// stack holds the layers of a network computing in T. Activations are
+// evaluated in float64 and rounded back to T.
+type stack[T matrix.Float] struct {
+	layers []*layer[T]
+}
+
 func NewNeuralNet(lr float64, lossFn func(y, yPred float64) float64) *NeuralNet {
 	return &NeuralNet{
 		LearningRate: lr,
 		LossFn:       lossFn,
+		net:          &stack[float64]{},
+	}
+}
+
+// NewNeuralNet32 returns a network that stores its weights and computes in
+// float32, halving the memory of the model.
+func NewNeuralNet32(lr float64, lossFn func(y, yPred float64) float64) *NeuralNet {
+	return &NeuralNet{
+		LearningRate: lr,
+		LossFn:       lossFn,
+		net:          &stack[float32]{},
 	}
 }
 
+// layerStack returns the layers of the network. A NeuralNet built as a
+// struct literal has none yet, and computes in float64.
+func (nn *NeuralNet) layerStack() network {
+	if nn.net == nil {
+		nn.net = &stack[float64]{}
+	}
+
+	return nn.net
+}
+
+// Precision returns the precision the network computes in.
+func (nn *NeuralNet) Precision() Precision {
+	return nn.layerStack().precision()
+}
+
+// Convert returns a copy of the network computing in precision p. Weights
+// are rounded to the nearest float32 when narrowing.
+func (nn *NeuralNet) Convert(p Precision) (*NeuralNet, error) {
+	result := NeuralNet{
+		LossFn:       nn.LossFn,
+		LearningRate: nn.LearningRate,
+		hasTrained:   nn.hasTrained,
+	}
+
+	switch p {
+	case Float64:
+		result.net = convertStack[float64](nn.layerStack())
+	case Float32:
+		result.net = convertStack[float32](nn.layerStack())
+	default:
+		return nil, fmt.Errorf("unsupported precision: %d", p)
+	}
+
+	return &result, nil
+}
+
 func (nn *NeuralNet) Train(x, y []float64) error {
-	lenLayers := len(nn.layers)
+	err := nn.checkTopology()
 
-	if !(lenLayers >= 3 && nn.layers[0].IsInputLayer && nn.layers[lenLayers-1].IsOutputLayer) {
-		return errors.New("training requires 1 input layer, n hidden layers, and 1 output layer")
+	if err != nil {
+		return err
 	}
 
-	inputLayer := nn.layers[0]
+	err = nn.layerStack().train(x, y, nn.LearningRate)
+
+	if err != nil {
+		return err
+	}
+
+	nn.hasTrained = true
+
+	return nil
+}
+
+func (nn *NeuralNet) checkTopology() error {
+	lenLayers := nn.layerStack().size()
+
+	if lenLayers >= 3 {
+		isInput, _ := nn.layerStack().flags(0)
+		_, isOutput := nn.layerStack().flags(lenLayers - 1)
+
+		if isInput && isOutput {
+			return nil
+		}
+	}
+
+	return errors.New("training requires 1 input layer, n hidden layers, and 1 output layer")
+}
+
+func (nn *NeuralNet) Predict(x []float64) ([]float64, error) {
+	if !nn.hasTrained {
+		return nil, errors.New("neuralnet has not been trained yet")
+	}
+
+	return nn.layerStack().predict(x)
+}
+
+func (s *stack[T]) precision() Precision {
+	var zero T
+
+	if _, ok := any(zero).(float32); ok {
+		return Float32
+	}
+
+	return Float64
+}
+
+func (s *stack[T]) size() int {
+	return len(s.layers)
+}
+
+func (s *stack[T]) flags(i int) (bool, bool) {
+	return s.layers[i].IsInputLayer, s.layers[i].IsOutputLayer
+}
+
+func (s *stack[T]) train(x, y []float64, lr float64) error {
+	lenLayers := len(s.layers)
+	inputLayer := s.layers[0]
 
 	if len(x) != inputLayer.Units {
 		return fmt.Errorf("train expected %d x length, got %d", inputLayer.Units, len(x))
 	}
 
-	outputLayer := nn.layers[lenLayers-1]
+	outputLayer := s.layers[lenLayers-1]
 
 	if len(y) != outputLayer.Units {
 		return fmt.Errorf("train expected %d y length, got %d", outputLayer.Units, len(y))
 	}
 
-	err := nn.forwardPropagate(x)
+	err := s.forwardPropagate(x)
 
 	if err != nil {
 		return fmt.Errorf("failed to train model: %w", err)
 	}
 
-	err = nn.backwardPropagate(y)
+	err = s.backwardPropagate(y, T(lr))
 
 	if err != nil {
 		return fmt.Errorf("failed to train model: %w", err)
 	}
 
-	nn.hasTrained = true
-
 	return nil
 }
 
-func (nn *NeuralNet) Predict(x []float64) ([]float64, error) {
-	if !nn.hasTrained {
-		return nil, errors.New("neuralnet has not been trained yet")
-	}
-
-	err := nn.forwardPropagate(x)
+func (s *stack[T]) predict(x []float64) ([]float64, error) {
+	err := s.forwardPropagate(x)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to predict model: %w", err)
 	}
 
-	lenLayers := len(nn.layers)
-	outputLayer := nn.layers[lenLayers-1]
+	lenLayers := len(s.layers)
+	outputLayer := s.layers[lenLayers-1]
 
-	return outputLayer.Values, nil
+	return toFloat64(outputLayer.Values), nil
 }
 
-func (nn *NeuralNet) forwardPropagate(x []float64) error {
-	copy(nn.layers[0].Values, x)
+func (s *stack[T]) forwardPropagate(x []float64) error {
+	input := s.layers[0].Values
 
-	for i := range len(nn.layers) - 1 {
-		current := nn.layers[i]
-		next := nn.layers[i+1]
+	if len(x) != len(input) {
+		return fmt.Errorf("expected %d input values, got %d", len(input), len(x))
+	}
 
-		for unit := range next.Units {
-			w, err := current.Weights.SliceRow(unit)
+	for i, v := range x {
+		input[i] = T(v)
+	}
 
-			if err != nil {
-				return fmt.Errorf("failed to get weights when slicing row: %w", err)
-			}
+	return s.propagateFrom(0)
+}
 
-			dot, err := vector.Multiply(current.Values, w)
+func (s *stack[T]) propagateFrom(start int) error {
+	for i := start; i < len(s.layers)-1; i++ {
+		current := s.layers[i]
+		next := s.layers[i+1]
+
+		for unit := range next.Units {
+			dot, err := dotProduct(current.Values, current.weightRow(unit))
 
 			if err != nil {
 				return fmt.Errorf("failed to forward propagate: %w", err)
@@ -99,72 +221,153 @@ func (nn *NeuralNet) forwardPropagate(x []float64) error {
 
 			z := dot + next.Biases[unit]
 			next.ZValues[unit] = z
-			next.Values[unit] = next.Activation.Fn(z)
+			next.Values[unit] = T(next.Activation.Fn(float64(z)))
 		}
 	}
 
 	return nil
 }
 
-func (nn *NeuralNet) backwardPropagate(y []float64) error {
-	lenLayers := len(nn.layers)
+func (s *stack[T]) backwardPropagate(y []float64, lr T) error {
+	lenLayers := len(s.layers)
 
 	// Start from output layer and move backward
 	for i := lenLayers - 1; i > 0; i-- {
-		current := nn.layers[i]
-		prev := nn.layers[i-1]
+		current := s.layers[i]
+		prev := s.layers[i-1]
 
 		for j := range current.Units {
-			var delta float64
+			var delta T
 
 			if current.IsOutputLayer {
 				// Output layer: derivative of loss
-				delta = current.Values[j] - y[j]
+				delta = current.Values[j] - T(y[j])
 			} else {
 				// Hidden layer: sum of next layer gradients * corresponding weights
-				next := nn.layers[i+1]
+				next := s.layers[i+1]
 
 				// We need to sum over all units in the next layer
-				sum := 0.0
+				sum := T(0)
 				for k := range next.Units {
 					// The weight is from current unit j to next unit k
-					w, err := current.Weights.At(k, j) // Note: using current's weights, not next's
-					if err != nil {
-						return fmt.Errorf("failed to access weight during backprop: %w", err)
-					}
+					w := current.weightRow(k)[j] // Note: using current's weights, not next's
 					sum += next.Gradients[k] * w
 				}
 				delta = sum
 			}
 
 			// Derivative of activation: dA/dZ
-			grad := delta * current.Activation.FnPrime(current.ZValues[j])
-			grad = clipGradient(grad, 1.0)
+			grad := delta * T(current.Activation.FnPrime(float64(current.ZValues[j])))
+			grad = T(clipGradient(float64(grad), 1.0))
 			current.Gradients[j] = grad
 
 			// Update weights between prev and current layers
+			weights := prev.weightRow(j)
+
+			// Zero inputs leave the weight unchanged (sparse inputs), unless
+			// the gradient is NaN, which reaches every weight as in dense input
+			skipZero := !math.IsNaN(float64(grad))
+
 			for k := range prev.Units {
-				oldWeight, err := prev.Weights.At(j, k)
-				if err != nil {
-					return fmt.Errorf("failed to access previous weight: %w", err)
+				if skipZero && prev.Values[k] == 0 {
+					continue
 				}
 
 				// Weight update: w = w - learning_rate * gradient * input
-				newWeight := oldWeight - nn.LearningRate*grad*prev.Values[k]
-				err = prev.Weights.Set(j, k, newWeight)
-				if err != nil {
-					return fmt.Errorf("failed to set new weight: %w", err)
-				}
+				weights[k] -= lr * grad * prev.Values[k]
 			}
 
 			// Update bias
-			current.Biases[j] -= nn.LearningRate * grad
+			current.Biases[j] -= lr * grad
 		}
 	}
 
 	return nil
 }
 
+// dotProduct uses the SIMD vector kernels for float64.
+func dotProduct[T matrix.Float](x, y []T) (T, error) {
+	if x64, ok := any(x).([]float64); ok {
+		dot, err := vector.Multiply(x64, any(y).([]float64))
+
+		return T(dot), err
+	}
+
+	if len(x) != len(y) {
+		return 0, fmt.Errorf("vector lengths differ: %d and %d", len(x), len(y))
+	}
+
+	sum := T(0)
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+
+	return sum, nil
+}
+
+// toFloat64 returns float64 values as they are and widens float32 ones.
+func toFloat64[T matrix.Float](v []T) []float64 {
+	if v64, ok := any(v).([]float64); ok {
+		return v64
+	}
+
+	result := make([]float64, len(v))
+	for i, s := range v {
+		result[i] = float64(s)
+	}
+
+	return result
+}
+
+func convertFloats[T, S matrix.Float](v []S) []T {
+	if v == nil {
+		return nil
+	}
+
+	result := make([]T, len(v))
+	for i, s := range v {
+		result[i] = T(s)
+	}
+
+	return result
+}
+
+func convertStack[T matrix.Float](net network) *stack[T] {
+	switch src := net.(type) {
+	case *stack[float64]:
+		return convertLayers[T](src.layers)
+	case *stack[float32]:
+		return convertLayers[T](src.layers)
+	}
+
+	panic("unknown network precision")
+}
+
+func convertLayers[T, S matrix.Float](layers []*layer[S]) *stack[T] {
+	result := stack[T]{layers: make([]*layer[T], len(layers))}
+
+	for i, l := range layers {
+		c := layer[T]{
+			Units:         l.Units,
+			Values:        convertFloats[T](l.Values),
+			ZValues:       convertFloats[T](l.ZValues),
+			Weights:       convertFloats[T](l.Weights),
+			Biases:        convertFloats[T](l.Biases),
+			Gradients:     convertFloats[T](l.Gradients),
+			Activation:    l.Activation,
+			IsInputLayer:  l.IsInputLayer,
+			IsOutputLayer: l.IsOutputLayer,
+		}
+		result.layers[i] = &c
+
+		if i > 0 {
+			result.layers[i-1].NextLayer = &c
+		}
+	}
+
+	return &result
+}
+
 func clipGradient(grad, threshold float64) float64 {
     if grad > threshold {
         return threshold
@@ -176,23 +379,25 @@ func clipGradient(grad, threshold float64) float64 {
 }
 
 func (nn *NeuralNet) AddInputLayer(units int) error {
-	if len(nn.layers) > 0 {
+	if nn.layerStack().size() > 0 {
 		return errors.New("only first layer must be an input layer")
 	}
 
-	return nn.addLayer(units, activation.Identity(), true, false)
+	return nn.layerStack().addLayer(units, activation.Identity(), true, false)
 }
 
 func (nn *NeuralNet) AddHiddenLayer(units int, activation *activation.Activation) error {
-	if len(nn.layers) == 0 || !nn.layers[0].IsInputLayer {
-		return errors.New("first layer must be an input layer")
+	err := nn.checkCanAdd()
+
+	if err != nil {
+		return err
 	}
 
-	if nn.layers[len(nn.layers)-1].IsOutputLayer {
+	if _, isOutput := nn.layerStack().flags(nn.layerStack().size() - 1); isOutput {
 		return errors.New("cannot add hidden layers after output layer")
 	}
 
-	err := nn.addLayer(units, activation, false, false)
+	err = nn.layerStack().addLayer(units, activation, false, false)
 
 	if err != nil {
 		return fmt.Errorf("failed to add hidden layer: %w", err)
@@ -202,86 +407,74 @@ func (nn *NeuralNet) AddHiddenLayer(units int, activation *activation.Activation
 }
 
 func (nn *NeuralNet) AddOutputLayer(units int, activation *activation.Activation) error {
-	if len(nn.layers) == 0 || !nn.layers[0].IsInputLayer {
-		return errors.New("first layer must be an input layer")
+	err := nn.checkCanAdd()
+
+	if err != nil {
+		return err
 	}
 
-	if nn.layers[len(nn.layers)-1].IsOutputLayer {
+	if _, isOutput := nn.layerStack().flags(nn.layerStack().size() - 1); isOutput {
 		return errors.New("output layer already exists")
 	}
 
-	return nn.addLayer(units, activation, false, true)
+	return nn.layerStack().addLayer(units, activation, false, true)
+}
+
+func (nn *NeuralNet) checkCanAdd() error {
+	if nn.layerStack().size() == 0 {
+		return errors.New("first layer must be an input layer")
+	}
+
+	if isInput, _ := nn.layerStack().flags(0); !isInput {
+		return errors.New("first layer must be an input layer")
+	}
+
+	return nil
 }
 
-func (nn *NeuralNet) addLayer(units int, activation *activation.Activation, isInputLayer, isOutputLayer bool) error {
-	layer, err := newLayer(units, activation, isInputLayer, isOutputLayer)
+func (s *stack[T]) addLayer(units int, activation *activation.Activation, isInputLayer, isOutputLayer bool) error {
+	layer, err := newLayer[T](units, activation, isInputLayer, isOutputLayer)
 
 	if err != nil {
 		return fmt.Errorf("failed to add input layer: %w", err)
 	}
 
 	if !isInputLayer {
-		layer.Biases = randomBiases(units)
+		layer.Biases = randomBiases[T](units)
 	}
 
-	nn.layers = append(nn.layers, layer)
+	s.layers = append(s.layers, layer)
 
-	lenLayers := len(nn.layers)
+	lenLayers := len(s.layers)
 
 	if lenLayers >= 2 {
-		current := nn.layers[lenLayers-2]
-		next := nn.layers[lenLayers-1]
+		current := s.layers[lenLayers-2]
+		next := s.layers[lenLayers-1]
 
-		err := connectLayers(current, next)
-		if err != nil {
-			return fmt.Errorf("failed to connect layers: %w", err)
-		}
+		connectLayers(current, next)
 	}
 
 	return nil
 }
 
-func connectLayers(current, next *layer) error {
+func connectLayers[T matrix.Float](current, next *layer[T]) {
 	current.NextLayer = next
+	current.Weights = make([]T, next.Units*current.Units)
 
-	weights, err := matrix.NewMatrix(next.Units, current.Units)
-
-	if err != nil {
-		return fmt.Errorf("failed to initialize weights: %w", err)
+	for i := range current.Weights {
+		current.Weights[i] = T(glorotInit(next.Units, current.Units))
 	}
-
-	err = randomWeights(weights)
-	if err != nil {
-		return fmt.Errorf("failed to initialize weights: %w", err)
-	}
-
-	current.Weights = weights
-
-	return nil
-}
-
-func randomWeights(weights *matrix.Matrix) error {
-	for row := range weights.Rows {
-		for col := range weights.Cols {
-			err := weights.Set(row, col, glorotInit(weights.Rows, weights.Cols))
-			if err != nil {
-				return fmt.Errorf("failed to set matrix at (%d, %d): %w", row, col, err)
-			}
-		}
-	}
-
-	return nil
 }
 
-func randomBiases(units int) []float64 {
+func randomBiases[T matrix.Float](units int) []T {
 	if units < 1 {
 		panic("failed to get random vector, invalid units length")
 	}
 
-	vec := make([]float64, units)
+	vec := make([]T, units)
 
 	for i := range vec {
-		vec[i] = rand.Float64()
+		vec[i] = T(rand.Float64())
 	}
 
 	return vec
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"gonn/neuralnet/activation"
 	"gonn/neuralnet/loss"
+	"gonn/preprocess"
 	"gonn/reader/csv"
+	"gonn/reader/parse"
 	"gonn/sample"
 	"math"
 	"strconv"
@@ -138,3 +140,126 @@ func TestRegression(t *testing.T) {
 
 	t.Logf("Overall MAPE: %f", sumMapes/float64(testRows))
 }
+
+// TestRegressionScaled trains on standardized features, which converges at
+// a far higher learning rate than the raw features in TestRegression.
+func TestRegressionScaled(t *testing.T) {
+	path, err := sample.GetSampleFilePath("winequality-red.csv")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	r := csv.NewReader(path, true, ';')
+
+	for i, name := range []string{"fixed acidity", "volatile acidity", "citric acid", "residual sugar",
+		"chlorides", "free sulfur dioxide", "total sulfur dioxide", "density", "pH", "sulphates",
+		"alcohol", "quality"} {
+		r.DefineColumn(i, name, parse.Float)
+	}
+
+	err = r.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	parts, err := r.DataTable.RandomSplit(1, 0.95, 0.05)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	features := r.DataTable.Cols[0:11]
+	scaler := preprocess.NewStandardScaler()
+
+	err = scaler.Fit(parts[0], features...)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	train, err := scaler.Transform(parts[0])
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	test, err := scaler.Transform(parts[1])
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	nn := NewNeuralNet(0.01, loss.MSE)
+
+	_ = nn.AddInputLayer(11)
+	_ = nn.AddHiddenLayer(11, activation.ReLU())
+	_ = nn.AddOutputLayer(1, activation.IdentityRound())
+
+	m := train.Matrix
+
+	for row := range m.Rows {
+		v, _ := m.RowView(row)
+
+		err = nn.Train(v[0:11], v[11:12])
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	m = test.Matrix
+	sumMapes := 0.0
+
+	for row := range m.Rows {
+		v, _ := m.RowView(row)
+
+		yPred, err := nn.Predict(v[0:11])
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		sumMapes += math.Abs((v[11] - yPred[0]) / v[11])
+	}
+
+	mape := sumMapes / float64(m.Rows)
+
+	if mape > 0.15 {
+		t.Errorf("expected overall MAPE below %f, got %f", 0.15, mape)
+	}
+}
+
+func TestStructLiteral(t *testing.T) {
+	nn := &NeuralNet{LearningRate: 0.1, LossFn: loss.MSE}
+
+	if nn.Precision() != Float64 {
+		t.Errorf("expected precision %d, got %d", Float64, nn.Precision())
+	}
+
+	err := nn.AddInputLayer(2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err = nn.AddHiddenLayer(3, activation.Sigmoid())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err = nn.AddOutputLayer(1, activation.Identity())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err = nn.Train([]float64{1, 0}, []float64{1})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = (&NeuralNet{}).Predict([]float64{1, 0})
+
+	if err == nil {
+		t.Errorf("expected error predicting with no layers, got nil")
+	}
+}
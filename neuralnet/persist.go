@@ -0,0 +1,248 @@
+package neuralnet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"gonn/internal/binio"
+	"gonn/neuralnet/activation"
+	"io"
+)
+
+// Precision is the float width a network computes in, and how Save
+// encodes its weights and biases. Saving as Float32 halves the file size.
+type Precision uint8
+
+const (
+	Float32 Precision = 4
+	Float64 Precision = 8
+)
+
+var modelMagic = [4]byte{'G', 'O', 'N', 'N'}
+
+const modelVersion = 1
+
+func (nn *NeuralNet) Save(w io.Writer, p Precision) error {
+	if p != Float32 && p != Float64 {
+		return fmt.Errorf("unsupported precision: %d", p)
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := encoder{Encoder: binio.Encoder{W: bw}, p: p}
+
+	enc.Write(modelMagic)
+	enc.Write(uint8(modelVersion))
+	enc.Write(uint8(p))
+	enc.Write(nn.LearningRate)
+	enc.Write(nn.hasTrained)
+
+	err := nn.layerStack().encode(&enc)
+
+	if err != nil {
+		return err
+	}
+
+	if enc.Err != nil {
+		return fmt.Errorf("failed to save model: %w", enc.Err)
+	}
+
+	err = bw.Flush()
+
+	if err != nil {
+		return fmt.Errorf("failed to save model: %w", err)
+	}
+
+	return nil
+}
+
+func (s *stack[T]) encode(enc *encoder) error {
+	lenLayers := len(s.layers)
+
+	if lenLayers < 2 || !s.layers[0].IsInputLayer || !s.layers[lenLayers-1].IsOutputLayer {
+		return errors.New("failed to save model: requires an input layer and an output layer")
+	}
+
+	for i, l := range s.layers[:lenLayers-1] {
+		if l.NextLayer == nil {
+			return fmt.Errorf("failed to save layer %d: not connected to the next layer", i)
+		}
+	}
+
+	enc.Write(uint32(lenLayers))
+
+	for i, l := range s.layers {
+		if l.Activation == nil || l.Activation.Name == "" {
+			return fmt.Errorf("failed to save layer %d: activation has no name", i)
+		}
+
+		enc.Write(uint32(l.Units))
+		enc.Write(l.IsInputLayer)
+		enc.Write(l.IsOutputLayer)
+		enc.Write(uint16(len(l.Activation.Name)))
+		enc.Write([]byte(l.Activation.Name))
+
+		if !l.IsInputLayer {
+			enc.floats(toFloat64(l.Biases))
+		}
+
+		if !l.IsOutputLayer {
+			enc.Write(uint32(l.NextLayer.Units))
+			enc.Write(uint32(l.Units))
+			enc.floats(toFloat64(l.Weights))
+		}
+	}
+
+	return nil
+}
+
+// Load restores a model written by Save in either precision. The model
+// computes in float64; Convert narrows it to float32. The loss function
+// is not serialized and must be supplied again.
+func Load(r io.Reader, lossFn func(y, yPred float64) float64) (*NeuralNet, error) {
+	dec := decoder{Decoder: binio.Decoder{R: bufio.NewReader(r)}}
+
+	var magic [4]byte
+	var version, precision uint8
+
+	dec.Read(&magic)
+	dec.Read(&version)
+	dec.Read(&precision)
+
+	if dec.Err != nil {
+		return nil, fmt.Errorf("failed to load model header: %w", dec.Err)
+	}
+
+	if magic != modelMagic {
+		return nil, errors.New("failed to load model: not a gonn model file")
+	}
+
+	if version != modelVersion {
+		return nil, fmt.Errorf("failed to load model: unsupported version %d", version)
+	}
+
+	dec.p = Precision(precision)
+
+	if dec.p != Float32 && dec.p != Float64 {
+		return nil, fmt.Errorf("failed to load model: unsupported precision %d", precision)
+	}
+
+	nn := NewNeuralNet(0, lossFn)
+	net := &stack[float64]{}
+	nn.net = net
+
+	var lenLayers uint32
+
+	dec.Read(&nn.LearningRate)
+	dec.Read(&nn.hasTrained)
+	dec.Read(&lenLayers)
+
+	for i := range int(lenLayers) {
+		if dec.Err != nil {
+			break
+		}
+
+		var units uint32
+		var isInput, isOutput bool
+		var nameLen uint16
+
+		dec.Read(&units)
+		dec.Read(&isInput)
+		dec.Read(&isOutput)
+		dec.Read(&nameLen)
+
+		name := make([]byte, nameLen)
+		dec.Read(name)
+
+		if dec.Err != nil {
+			break
+		}
+
+		// Every layer is followed by its biases or weights, which are
+		// read before the layer is allocated, so a corrupt unit count
+		// fails at the end of the stream instead of allocating
+		if isInput && isOutput {
+			return nil, fmt.Errorf("failed to load layer %d: cannot be both input and output", i)
+		}
+
+		act, err := activation.ByName(string(name))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to load layer %d: %w", i, err)
+		}
+
+		var biases, weights []float64
+
+		if !isInput {
+			biases = dec.floats(uint64(units))
+		}
+
+		if !isOutput {
+			var rows, cols uint32
+
+			dec.Read(&rows)
+			dec.Read(&cols)
+
+			if dec.Err == nil && cols != units {
+				return nil, fmt.Errorf("failed to load layer %d: weights have %d columns, expected %d",
+					i, cols, units)
+			}
+
+			weights = dec.floats(uint64(rows) * uint64(cols))
+		}
+
+		if dec.Err != nil {
+			break
+		}
+
+		l, err := newLayer[float64](int(units), act, isInput, isOutput)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to load layer %d: %w", i, err)
+		}
+
+		l.Biases = biases
+		l.Weights = weights
+
+		if len(net.layers) > 0 {
+			prev := net.layers[len(net.layers)-1]
+
+			if len(prev.Weights) != prev.Units*l.Units {
+				return nil, fmt.Errorf("failed to load layer %d: weights do not connect to layer %d",
+					i-1, i)
+			}
+
+			prev.NextLayer = l
+		}
+
+		net.layers = append(net.layers, l)
+	}
+
+	if dec.Err != nil {
+		return nil, fmt.Errorf("failed to load model: %w", dec.Err)
+	}
+
+	if uint32(len(net.layers)) != lenLayers {
+		return nil, errors.New("failed to load model: truncated layer list")
+	}
+
+	return nn, nil
+}
+
+// encoder and decoder carry the precision the weights are stored in.
+type encoder struct {
+	binio.Encoder
+	p Precision
+}
+
+func (e *encoder) floats(data []float64) {
+	e.Floats(data, e.p == Float32)
+}
+
+type decoder struct {
+	binio.Decoder
+	p Precision
+}
+
+func (d *decoder) floats(expected uint64) []float64 {
+	return d.Floats(expected, d.p == Float32)
+}
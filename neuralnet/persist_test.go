@@ -0,0 +1,294 @@
+package neuralnet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"gonn/matrix"
+	"gonn/neuralnet/activation"
+	"gonn/neuralnet/loss"
+	"math"
+	"runtime"
+	"testing"
+)
+
+func newTestNet(t *testing.T) *NeuralNet {
+	t.Helper()
+
+	nn := NewNeuralNet(0.01, loss.MSE)
+
+	err := nn.AddInputLayer(3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err = nn.AddHiddenLayer(4, activation.ReLU())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err = nn.AddOutputLayer(2, activation.Sigmoid())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err = nn.Train([]float64{0.5, -1, 2}, []float64{1, 0})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return nn
+}
+
+func TestSaveLoad(t *testing.T) {
+	for _, tc := range []struct {
+		precision Precision
+		tol       float64
+	}{
+		{Float64, 0},
+		{Float32, 1e-6},
+	} {
+		nn := newTestNet(t)
+
+		var buf bytes.Buffer
+
+		err := nn.Save(&buf, tc.precision)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		loaded, err := Load(&buf, loss.MSE)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		x := []float64{1, 0.25, -0.5}
+
+		exp, _ := nn.Predict(x)
+		exp = append([]float64(nil), exp...)
+
+		got, err := loaded.Predict(x)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for i := range exp {
+			if math.Abs(exp[i]-got[i]) > tc.tol {
+				t.Errorf("precision %d: expected prediction %f, got %f", tc.precision, exp[i], got[i])
+			}
+		}
+	}
+}
+
+func TestSaveFloat32Smaller(t *testing.T) {
+	nn := newTestNet(t)
+
+	var b32, b64 bytes.Buffer
+
+	_ = nn.Save(&b32, Float32)
+	_ = nn.Save(&b64, Float64)
+
+	if b32.Len() >= b64.Len() {
+		t.Errorf("expected float32 model smaller than %d bytes, got %d", b64.Len(), b32.Len())
+	}
+}
+
+func TestSaveUnnamedActivation(t *testing.T) {
+	nn := NewNeuralNet(0.01, loss.MSE)
+	_ = nn.AddInputLayer(2)
+	_ = nn.AddHiddenLayer(2, &activation.Activation{
+		Fn:      func(z float64) float64 { return z },
+		FnPrime: func(z float64) float64 { return 1 },
+	})
+	_ = nn.AddOutputLayer(1, activation.Identity())
+
+	var buf bytes.Buffer
+
+	err := nn.Save(&buf, Float64)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestSaveIncomplete(t *testing.T) {
+	nn := NewNeuralNet(0.01, loss.MSE)
+
+	var buf bytes.Buffer
+
+	err := nn.Save(&buf, Float64)
+
+	if err == nil {
+		t.Errorf("expected error saving a network with no layers, got nil")
+	}
+
+	_ = nn.AddInputLayer(2)
+	_ = nn.AddHiddenLayer(2, activation.ReLU())
+
+	err = nn.Save(&buf, Float64)
+
+	if err == nil {
+		t.Errorf("expected error saving a network with no output layer, got nil")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	_, err := Load(bytes.NewReader([]byte("not a model")), loss.MSE)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	nn := newTestNet(t)
+
+	var buf bytes.Buffer
+	_ = nn.Save(&buf, Float64)
+
+	_, err = Load(bytes.NewReader(buf.Bytes()[:buf.Len()-5]), loss.MSE)
+
+	if err == nil {
+		t.Errorf("expected error for truncated model, got nil")
+	}
+}
+
+func TestConvertPrecision(t *testing.T) {
+	nn := newTestNet(t)
+
+	nn32, err := nn.Convert(Float32)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if nn.Precision() != Float64 || nn32.Precision() != Float32 {
+		t.Errorf("expected precisions %d and %d, got %d and %d", Float64, Float32, nn.Precision(), nn32.Precision())
+	}
+
+	x, y := []float64{1, 0.25, -0.5}, []float64{0, 1}
+
+	for range 20 {
+		_ = nn.Train(x, y)
+
+		err = nn32.Train(x, y)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	exp, _ := nn.Predict(x)
+	got, err := nn32.Predict(x)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for i := range exp {
+		if math.Abs(exp[i]-got[i]) > 1e-5 {
+			t.Errorf("expected float32 prediction %f, got %f", exp[i], got[i])
+		}
+	}
+
+	back, _ := nn32.Convert(Float64)
+	again, _ := back.Predict(x)
+
+	for i := range got {
+		if math.Abs(again[i]-got[i]) > 1e-6 {
+			t.Errorf("expected widened prediction %f, got %f", got[i], again[i])
+		}
+	}
+
+	_, err = nn.Convert(Precision(2))
+
+	if err == nil {
+		t.Errorf("expected error for unknown precision, got nil")
+	}
+}
+
+func TestNeuralNet32(t *testing.T) {
+	nn := NewNeuralNet32(0.05, loss.MSE)
+
+	_ = nn.AddInputLayer(2)
+	_ = nn.AddHiddenLayer(8, activation.Sigmoid())
+	_ = nn.AddOutputLayer(1, activation.Identity())
+
+	x := matrix.Matrix{Rows: 4, Cols: 2, Data: []float64{0, 0, 0, 1, 1, 0, 1, 1}}
+
+	for range 2000 {
+		for row := range x.Rows {
+			v := x.Data[row*2 : row*2+2]
+
+			err := nn.Train(v, []float64{v[0] + v[1]})
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+	}
+
+	for row := range x.Rows {
+		v := x.Data[row*2 : row*2+2]
+		yPred, _ := nn.Predict(v)
+
+		if math.Abs(yPred[0]-(v[0]+v[1])) > 0.1 {
+			t.Errorf("expected %v to predict %f, got %f", v, v[0]+v[1], yPred[0])
+		}
+	}
+
+	var buf bytes.Buffer
+
+	err := nn.Save(&buf, Float32)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	loaded, err := Load(&buf, loss.MSE)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp, _ := nn.Predict([]float64{1, 1})
+	got, _ := loaded.Predict([]float64{1, 1})
+
+	if math.Abs(exp[0]-got[0]) > 1e-6 {
+		t.Errorf("expected loaded prediction %f, got %f", exp[0], got[0])
+	}
+}
+
+func TestLoadLargeHeader(t *testing.T) {
+	var buf bytes.Buffer
+
+	buf.Write(modelMagic[:])
+	_ = binary.Write(&buf, binary.LittleEndian, uint8(modelVersion))
+	_ = binary.Write(&buf, binary.LittleEndian, uint8(Float64))
+	_ = binary.Write(&buf, binary.LittleEndian, 0.01)
+	_ = binary.Write(&buf, binary.LittleEndian, true)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(2))
+
+	// An input layer of 2^28 units whose weights are missing
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(1<<28))
+	_ = binary.Write(&buf, binary.LittleEndian, true)
+	_ = binary.Write(&buf, binary.LittleEndian, false)
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(len("identity")))
+	buf.WriteString("identity")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(1<<28))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(1<<30))
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	_, err := Load(&buf, loss.MSE)
+
+	runtime.ReadMemStats(&after)
+
+	if err == nil {
+		t.Fatal("expected error for missing weights, got nil")
+	}
+
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1<<20 {
+		t.Errorf("expected a small allocation for a header without data, got %d bytes", alloc)
+	}
+}
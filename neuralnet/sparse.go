@@ -0,0 +1,145 @@
+package neuralnet
+
+import (
+	"errors"
+	"fmt"
+	"gonn/matrix"
+)
+
+// TrainSparse trains on every row of a sparse input batch x against the
+// matching row of y. The input layer only touches the non-zero features.
+func (nn *NeuralNet) TrainSparse(x *matrix.CSR, y *matrix.Matrix) error {
+	err := nn.checkTopology()
+
+	if err != nil {
+		return err
+	}
+
+	err = nn.layerStack().trainSparse(x, y, nn.LearningRate)
+
+	if err != nil {
+		return err
+	}
+
+	nn.hasTrained = true
+
+	return nil
+}
+
+// PredictSparse returns one row of output values per row of x. The first
+// layer is computed for the whole batch as a sparse x dense product.
+func (nn *NeuralNet) PredictSparse(x *matrix.CSR) (*matrix.Matrix, error) {
+	if !nn.hasTrained {
+		return nil, errors.New("neuralnet has not been trained yet")
+	}
+
+	return nn.layerStack().predictSparse(x)
+}
+
+func (s *stack[T]) trainSparse(x *matrix.CSR, y *matrix.Matrix, lr float64) error {
+	inputLayer := s.layers[0]
+	outputLayer := s.layers[len(s.layers)-1]
+
+	if x.Cols != inputLayer.Units {
+		return fmt.Errorf("train expected %d x columns, got %d", inputLayer.Units, x.Cols)
+	}
+
+	if y.Cols != outputLayer.Units || y.Rows != x.Rows {
+		return fmt.Errorf("train expected %dx%d y, got %dx%d",
+			x.Rows, outputLayer.Units, y.Rows, y.Cols)
+	}
+
+	for row := range x.Rows {
+		indices, values := x.Row(row)
+
+		err := s.forwardPropagateSparse(indices, values)
+
+		if err != nil {
+			return fmt.Errorf("failed to train model: %w", err)
+		}
+
+		err = s.backwardPropagate(y.Data[row*y.Cols:(row+1)*y.Cols], T(lr))
+
+		if err != nil {
+			return fmt.Errorf("failed to train model: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *stack[T]) predictSparse(x *matrix.CSR) (*matrix.Matrix, error) {
+	inputLayer := s.layers[0]
+	first := s.layers[1]
+	outputLayer := s.layers[len(s.layers)-1]
+
+	if x.Cols != inputLayer.Units {
+		return nil, fmt.Errorf("predict expected %d x columns, got %d", inputLayer.Units, x.Cols)
+	}
+
+	w := matrix.Matrix{
+		Rows: first.Units,
+		Cols: inputLayer.Units,
+		Data: toFloat64(inputLayer.Weights),
+	}
+
+	z, err := x.Multiply(w.Transpose())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to predict model: %w", err)
+	}
+
+	result, err := matrix.NewMatrix(x.Rows, outputLayer.Units)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to predict model: %w", err)
+	}
+
+	for row := range x.Rows {
+		for unit := range first.Units {
+			zv := T(z.Data[row*z.Cols+unit]) + first.Biases[unit]
+			first.ZValues[unit] = zv
+			first.Values[unit] = T(first.Activation.Fn(float64(zv)))
+		}
+
+		err := s.propagateFrom(1)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to predict model: %w", err)
+		}
+
+		out := result.Data[row*result.Cols : (row+1)*result.Cols]
+
+		for j, v := range outputLayer.Values {
+			out[j] = float64(v)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *stack[T]) forwardPropagateSparse(indices []int, values []float64) error {
+	input := s.layers[0]
+	first := s.layers[1]
+
+	clear(input.Values)
+
+	for p, idx := range indices {
+		input.Values[idx] = T(values[p])
+	}
+
+	for unit := range first.Units {
+		row := input.weightRow(unit)
+		dot := T(0)
+
+		for p, idx := range indices {
+			dot += row[idx] * T(values[p])
+		}
+
+		z := dot + first.Biases[unit]
+		first.ZValues[unit] = z
+		first.Values[unit] = T(first.Activation.Fn(float64(z)))
+	}
+
+	return s.propagateFrom(1)
+}
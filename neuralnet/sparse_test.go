@@ -0,0 +1,108 @@
+package neuralnet
+
+import (
+	"gonn/matrix"
+	"gonn/neuralnet/activation"
+	"gonn/neuralnet/loss"
+	"math"
+	"testing"
+)
+
+func TestPredictSparseMatchesDense(t *testing.T) {
+	nn := NewNeuralNet(0.01, loss.MSE)
+
+	err := nn.AddInputLayer(6)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err = nn.AddHiddenLayer(4, activation.Sigmoid())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	err = nn.AddOutputLayer(2, activation.Identity())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	x, err := matrix.NewCSR(3, 6, []matrix.Triplet{
+		{Row: 0, Col: 1, Value: 1},
+		{Row: 1, Col: 3, Value: 1},
+		{Row: 1, Col: 5, Value: 0.5},
+		{Row: 2, Col: 0, Value: 2},
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	y, _ := matrix.NewMatrix(3, 2)
+	copy(y.Data, []float64{1, 0, 0, 1, 1, 1})
+
+	err = nn.TrainSparse(x, y)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	pred, err := nn.PredictSparse(x)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	dense := x.Dense()
+
+	for row := range dense.Rows {
+		v, _ := dense.SliceRow(row)
+
+		yPred, err := nn.Predict(v)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for j := range yPred {
+			if math.Abs(yPred[j]-pred.Data[row*pred.Cols+j]) > 1e-12 {
+				t.Errorf("expected sparse prediction %f for row %d, got %f",
+					yPred[j], row, pred.Data[row*pred.Cols+j])
+			}
+		}
+	}
+}
+
+func TestTrainSparseError(t *testing.T) {
+	nn := NewNeuralNet(0.01, loss.MSE)
+	_ = nn.AddInputLayer(3)
+	_ = nn.AddHiddenLayer(2, activation.ReLU())
+	_ = nn.AddOutputLayer(1, activation.Identity())
+
+	x, _ := matrix.NewCSR(2, 4, nil)
+	y, _ := matrix.NewMatrix(2, 1)
+
+	err := nn.TrainSparse(x, y)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestTrainZeroInputNaN(t *testing.T) {
+	nn := NewNeuralNet(0.01, loss.MSE)
+	_ = nn.AddInputLayer(2)
+	_ = nn.AddHiddenLayer(1, activation.Identity())
+	_ = nn.AddOutputLayer(1, activation.Identity())
+
+	err := nn.Train([]float64{0, 1}, []float64{math.NaN()})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	weights := nn.layerStack().(*stack[float64]).layers[0].weightRow(0)
+
+	for k, w := range weights {
+		if !math.IsNaN(w) {
+			t.Errorf("expected weight %d to be NaN, got %v", k, w)
+		}
+	}
+}
@@ -0,0 +1,490 @@
+package preprocess
+
+import (
+	"errors"
+	"fmt"
+	"gonn/datatable"
+	"gonn/matrix"
+	"gonn/vector"
+	"hash/fnv"
+	"math"
+	"strconv"
+)
+
+// Encoder turns one categorical column into numeric model input.
+type Encoder interface {
+	Fit(d *datatable.DataTable, col string) error
+	Transform(d *datatable.DataTable) (*datatable.DataTable, error)
+}
+
+// Unknown selects what Transform does with a category not seen by Fit.
+type Unknown uint8
+
+const (
+	// UnknownError fails the transform.
+	UnknownError Unknown = iota
+	// UnknownIgnore encodes the row as all zeros for one-hot, -1 for
+	// ordinal and the overall target mean for target encoding.
+	UnknownIgnore
+)
+
+var errNotFit = errors.New("encoder has not been fit")
+
+// OneHotEncoder replaces a column with one 0/1 column per category, named
+// "<col>_<category>" and appended after the remaining columns. Missing
+// cells are not a category and encode as all zeros.
+type OneHotEncoder struct {
+	Col        string
+	Categories []string
+	Unknown    Unknown
+	index      map[string]int
+}
+
+func NewOneHotEncoder(unknown Unknown) *OneHotEncoder {
+	return &OneHotEncoder{Unknown: unknown}
+}
+
+func (e *OneHotEncoder) Fit(d *datatable.DataTable, col string) error {
+	labels, missing, err := columnLabels(d, col)
+
+	if err != nil {
+		return fmt.Errorf("failed to fit one-hot encoder: %w", err)
+	}
+
+	e.Col = col
+	e.Categories, e.index = distinct(labels, missing)
+
+	return nil
+}
+
+// Columns returns the names of the columns added by Transform.
+func (e *OneHotEncoder) Columns() []string {
+	cols := make([]string, len(e.Categories))
+
+	for i, c := range e.Categories {
+		cols[i] = e.Col + "_" + c
+	}
+
+	return cols
+}
+
+func (e *OneHotEncoder) Transform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	if e.Categories == nil {
+		return nil, errNotFit
+	}
+
+	index := categoryIndex(e.Categories, e.index)
+	labels, missing, err := columnLabels(d, e.Col)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to one-hot encode: %w", err)
+	}
+
+	cols := make([][]float64, len(e.Categories))
+
+	for i := range cols {
+		cols[i] = make([]float64, len(labels))
+	}
+
+	for row, l := range labels {
+		if missing[row] {
+			continue
+		}
+
+		idx, ok := index[l]
+
+		if !ok {
+			if e.Unknown == UnknownError {
+				return nil, fmt.Errorf("failed to one-hot encode: unknown category %q in column %s", l, e.Col)
+			}
+
+			continue
+		}
+
+		cols[idx][row] = 1
+	}
+
+	return replaceColumn(d, e.Col, e.Columns(), cols)
+}
+
+// Decode returns the category of the largest value, e.g. of a softmax
+// output in the order of Columns.
+func (e *OneHotEncoder) Decode(v []float64) (string, error) {
+	if len(v) != len(e.Categories) {
+		return "", fmt.Errorf("decode expected %d values, got %d", len(e.Categories), len(v))
+	}
+
+	idx, err := vector.ArgMax(v)
+
+	if err != nil {
+		return "", err
+	}
+
+	return e.Categories[idx], nil
+}
+
+// DecodeMatrix decodes each row of a prediction matrix.
+func (e *OneHotEncoder) DecodeMatrix(m *matrix.Matrix) ([]string, error) {
+	labels := make([]string, m.Rows)
+
+	for row := range m.Rows {
+		l, err := e.Decode(m.Data[row*m.Cols : (row+1)*m.Cols])
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode row %d: %w", row, err)
+		}
+
+		labels[row] = l
+	}
+
+	return labels, nil
+}
+
+// OrdinalEncoder replaces a column in place with the index of each
+// category in order of first appearance. Missing cells stay missing.
+type OrdinalEncoder struct {
+	Col        string
+	Categories []string
+	Unknown    Unknown
+	index      map[string]int
+}
+
+func NewOrdinalEncoder(unknown Unknown) *OrdinalEncoder {
+	return &OrdinalEncoder{Unknown: unknown}
+}
+
+func (e *OrdinalEncoder) Fit(d *datatable.DataTable, col string) error {
+	labels, missing, err := columnLabels(d, col)
+
+	if err != nil {
+		return fmt.Errorf("failed to fit ordinal encoder: %w", err)
+	}
+
+	e.Col = col
+	e.Categories, e.index = distinct(labels, missing)
+
+	return nil
+}
+
+func (e *OrdinalEncoder) Transform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	if e.Categories == nil {
+		return nil, errNotFit
+	}
+
+	index := categoryIndex(e.Categories, e.index)
+	labels, missing, err := columnLabels(d, e.Col)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to ordinal encode: %w", err)
+	}
+
+	codes := make([]float64, len(labels))
+
+	for row, l := range labels {
+		if missing[row] {
+			codes[row] = math.NaN()
+			continue
+		}
+
+		idx, ok := index[l]
+
+		if !ok {
+			if e.Unknown == UnknownError {
+				return nil, fmt.Errorf("failed to ordinal encode: unknown category %q in column %s", l, e.Col)
+			}
+
+			idx = -1
+		}
+
+		codes[row] = float64(idx)
+	}
+
+	return replaceColumn(d, e.Col, []string{e.Col}, [][]float64{codes})
+}
+
+// Decode returns the category of a code, rounding regression outputs to
+// the nearest one.
+func (e *OrdinalEncoder) Decode(v float64) (string, error) {
+	idx := int(math.Round(v))
+
+	if math.IsNaN(v) || idx < 0 || idx >= len(e.Categories) {
+		return "", fmt.Errorf("code %v has no category", v)
+	}
+
+	return e.Categories[idx], nil
+}
+
+// TargetEncoder replaces a column in place with the mean of the target
+// column for each category, shrunk towards the overall mean by Smoothing
+// pseudo-rows so rare categories are not overfit. Missing cells encode as
+// Prior.
+type TargetEncoder struct {
+	Col       string
+	Target    string
+	Smoothing float64
+	Unknown   Unknown
+	Means     map[string]float64
+	Prior     float64
+}
+
+func NewTargetEncoder(target string, smoothing float64, unknown Unknown) *TargetEncoder {
+	return &TargetEncoder{
+		Target:    target,
+		Smoothing: smoothing,
+		Unknown:   unknown,
+	}
+}
+
+func (e *TargetEncoder) Fit(d *datatable.DataTable, col string) error {
+	labels, missing, err := columnLabels(d, col)
+
+	if err != nil {
+		return fmt.Errorf("failed to fit target encoder: %w", err)
+	}
+
+	y, err := numericColumn(d, e.Target)
+
+	if err != nil {
+		return fmt.Errorf("failed to fit target encoder: %w", err)
+	}
+
+	sums := make(map[string]float64)
+	counts := make(map[string]float64)
+	total, n := 0.0, 0.0
+
+	for row, l := range labels {
+		if missing[row] || math.IsNaN(y[row]) {
+			continue
+		}
+
+		sums[l] += y[row]
+		counts[l]++
+		total += y[row]
+		n++
+	}
+
+	if n == 0 {
+		return fmt.Errorf("failed to fit target encoder: target %s has no values", e.Target)
+	}
+
+	e.Col = col
+	e.Prior = total / n
+	e.Means = make(map[string]float64, len(sums))
+
+	for l, sum := range sums {
+		e.Means[l] = (sum + e.Smoothing*e.Prior) / (counts[l] + e.Smoothing)
+	}
+
+	return nil
+}
+
+func (e *TargetEncoder) Transform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	if e.Means == nil {
+		return nil, errNotFit
+	}
+
+	labels, missing, err := columnLabels(d, e.Col)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to target encode: %w", err)
+	}
+
+	values := make([]float64, len(labels))
+
+	for row, l := range labels {
+		if missing[row] {
+			values[row] = e.Prior
+			continue
+		}
+
+		mean, ok := e.Means[l]
+
+		if !ok {
+			if e.Unknown == UnknownError {
+				return nil, fmt.Errorf("failed to target encode: unknown category %q in column %s", l, e.Col)
+			}
+
+			mean = e.Prior
+		}
+
+		values[row] = mean
+	}
+
+	return replaceColumn(d, e.Col, []string{e.Col}, [][]float64{values})
+}
+
+// HashingEncoder replaces a column with Buckets count columns, named
+// "<col>_hash<i>", setting the bucket of each category's FNV-1a hash. It
+// needs no vocabulary, so unknown categories are never an error. Missing
+// cells set no bucket.
+type HashingEncoder struct {
+	Col     string
+	Buckets int
+}
+
+func NewHashingEncoder(buckets int) *HashingEncoder {
+	return &HashingEncoder{Buckets: buckets}
+}
+
+func (e *HashingEncoder) Fit(d *datatable.DataTable, col string) error {
+	if e.Buckets < 1 {
+		return fmt.Errorf("failed to fit hashing encoder: %d buckets", e.Buckets)
+	}
+
+	_, _, err := columnLabels(d, col)
+
+	if err != nil {
+		return fmt.Errorf("failed to fit hashing encoder: %w", err)
+	}
+
+	e.Col = col
+
+	return nil
+}
+
+// Bucket returns the column index a category is hashed to.
+func (e *HashingEncoder) Bucket(category string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(category))
+
+	return int(h.Sum32() % uint32(e.Buckets))
+}
+
+func (e *HashingEncoder) Transform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	if e.Col == "" {
+		return nil, errNotFit
+	}
+
+	labels, missing, err := columnLabels(d, e.Col)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash encode: %w", err)
+	}
+
+	names := make([]string, e.Buckets)
+	cols := make([][]float64, e.Buckets)
+
+	for i := range cols {
+		names[i] = e.Col + "_hash" + strconv.Itoa(i)
+		cols[i] = make([]float64, len(labels))
+	}
+
+	for row, l := range labels {
+		if !missing[row] {
+			cols[e.Bucket(l)][row] = 1
+		}
+	}
+
+	return replaceColumn(d, e.Col, names, cols)
+}
+
+// columnLabels returns the category of every row: the level of String and
+// Categorical columns and the formatted value of any other column. Missing
+// cells are flagged in missing rather than given a label, so they never
+// match a real category.
+func columnLabels(d *datatable.DataTable, col string) (labels []string, missing []bool, err error) {
+	typ, err := d.ColumnType(col)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labels = make([]string, d.Matrix.Rows)
+	missing = make([]bool, d.Matrix.Rows)
+
+	for row := range labels {
+		if typ == datatable.String || typ == datatable.Categorical {
+			labels[row], err = d.StringAt(row, col)
+
+			if errors.Is(err, datatable.ErrMissing) {
+				missing[row] = true
+			} else if err != nil {
+				return nil, nil, err
+			}
+
+			continue
+		}
+
+		v, err := d.FloatAt(row, col)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if math.IsNaN(v) {
+			missing[row] = true
+			continue
+		}
+
+		labels[row] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	return labels, missing, nil
+}
+
+// distinct returns the present labels in order of first appearance and
+// the index of each.
+func distinct(labels []string, missing []bool) ([]string, map[string]int) {
+	categories := make([]string, 0)
+	index := make(map[string]int)
+
+	for i, l := range labels {
+		if missing != nil && missing[i] {
+			continue
+		}
+
+		if _, ok := index[l]; !ok {
+			index[l] = len(categories)
+			categories = append(categories, l)
+		}
+	}
+
+	return categories, index
+}
+
+// categoryIndex returns the index built by Fit, or a new one for an
+// encoder whose Categories were set directly. Transform never stores it,
+// so concurrent transforms only read the encoder.
+func categoryIndex(categories []string, index map[string]int) map[string]int {
+	if index == nil {
+		_, index = distinct(categories, nil)
+	}
+
+	return index
+}
+
+// replaceColumn returns a copy of d without col and with the given columns
+// appended. A single column named col is replaced in place instead.
+func replaceColumn(d *datatable.DataTable, col string, names []string, cols [][]float64) (*datatable.DataTable, error) {
+	out, err := d.Select(d.Cols...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy datatable: %w", err)
+	}
+
+	if len(names) == 1 && names[0] == col {
+		err = out.SetColumn(col, cols[0])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return out, nil
+	}
+
+	err = out.RemoveColumn(col)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range names {
+		err = out.AddColumn(name, cols[i])
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
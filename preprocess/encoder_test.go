@@ -0,0 +1,221 @@
+package preprocess
+
+import (
+	"gonn/datatable"
+	"gonn/matrix"
+	"sync"
+	"testing"
+)
+
+// irisSchema holds a length and a variety for encoder tests.
+var irisSchema = datatable.Schema{
+	{Name: "length", Type: datatable.Float},
+	{Name: "variety", Type: datatable.Categorical},
+}
+
+func TestOneHotEncoder(t *testing.T) {
+	d := newTable(t, irisSchema, [][]any{{0.0, "Setosa"}, {1.0, "Virginica"}, {2.0, "Setosa"}, {3.0, "Versicolor"}})
+	e := NewOneHotEncoder(UnknownError)
+
+	err := e.Fit(d, "variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, err := e.Transform(d)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := []string{"length", "variety_Setosa", "variety_Virginica", "variety_Versicolor"}
+
+	for i, c := range exp {
+		if out.Cols[i] != c {
+			t.Fatalf("expected columns %v, got %v", exp, out.Cols)
+		}
+	}
+
+	assertColumn(t, out, "variety_Setosa", []float64{1, 0, 1, 0})
+	assertColumn(t, out, "variety_Versicolor", []float64{0, 0, 0, 1})
+
+	_, err = e.Transform(newTable(t, irisSchema, [][]any{{0.0, "Unknown"}}))
+
+	if err == nil {
+		t.Errorf("expected error for unknown category, got nil")
+	}
+
+	e.Unknown = UnknownIgnore
+	out, err = e.Transform(newTable(t, irisSchema, [][]any{{0.0, "Unknown"}, {1.0, "Virginica"}}))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertColumn(t, out, "variety_Setosa", []float64{0, 0})
+	assertColumn(t, out, "variety_Virginica", []float64{0, 1})
+
+	pred := &matrix.Matrix{Rows: 2, Cols: 3, Data: []float64{0.1, 0.2, 0.7, 0.6, 0.3, 0.1}}
+	labels, err := e.DecodeMatrix(pred)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if labels[0] != "Versicolor" || labels[1] != "Setosa" {
+		t.Errorf("expected decoded labels %v, got %v", []string{"Versicolor", "Setosa"}, labels)
+	}
+}
+
+func TestOrdinalEncoder(t *testing.T) {
+	d := newTable(t, irisSchema, [][]any{{0.0, "Setosa"}, {1.0, "Virginica"}, {2.0, "Setosa"}})
+	e := NewOrdinalEncoder(UnknownIgnore)
+
+	err := e.Fit(d, "variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, err := e.Transform(newTable(t, irisSchema, [][]any{{0.0, "Virginica"}, {1.0, "Unknown"}, {2.0, "Setosa"}}))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertColumn(t, out, "variety", []float64{1, -1, 0})
+
+	l, err := e.Decode(0.8)
+
+	if err != nil || l != "Virginica" {
+		t.Errorf("expected decoded label %v, got %v (%v)", "Virginica", l, err)
+	}
+
+	_, err = e.Decode(-1)
+
+	if err == nil {
+		t.Errorf("expected error for code without category, got nil")
+	}
+}
+
+func TestTargetEncoder(t *testing.T) {
+	d := newTable(t, irisSchema, [][]any{{0.0, "a"}, {1.0, "a"}, {2.0, "b"}, {3.0, "b"}})
+
+	// length is the target: a rows are 0 and 1, b rows are 2 and 3
+	e := NewTargetEncoder("length", 2, UnknownIgnore)
+
+	err := e.Fit(d, "variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, err := e.Transform(newTable(t, irisSchema, [][]any{{0.0, "a"}, {1.0, "b"}, {2.0, "c"}}))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// (sum + 2 * 1.5) / (2 + 2)
+	assertColumn(t, out, "variety", []float64{1, 2, 1.5})
+}
+
+func TestHashingEncoder(t *testing.T) {
+	d := newTable(t, irisSchema, [][]any{{0.0, "Setosa"}, {1.0, "Virginica"}, {2.0, "Never seen"}})
+	e := NewHashingEncoder(4)
+
+	err := e.Fit(d, "variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, err := e.Transform(d)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if out.Matrix.Cols != 5 {
+		t.Fatalf("expected %d columns, got %d", 5, out.Matrix.Cols)
+	}
+
+	for row, l := range []string{"Setosa", "Virginica", "Never seen"} {
+		sum := 0.0
+
+		for b := range 4 {
+			v := out.Matrix.Data[row*5+1+b]
+			sum += v
+
+			if v == 1 && b != e.Bucket(l) {
+				t.Errorf("expected %s in bucket %d, got %d", l, e.Bucket(l), b)
+			}
+		}
+
+		if sum != 1 {
+			t.Errorf("expected one bucket set for %s, got %v", l, sum)
+		}
+	}
+}
+
+func TestEncoderMissing(t *testing.T) {
+	d := newTable(t, irisSchema, [][]any{{0.0, "NaN"}, {1.0, "Setosa"}, {2.0, "NaN"}, {3.0, nil}})
+	e := NewOneHotEncoder(UnknownError)
+
+	err := e.Fit(d, "variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(e.Categories) != 2 || e.Categories[0] != "NaN" || e.Categories[1] != "Setosa" {
+		t.Fatalf("expected categories [NaN Setosa], got %v", e.Categories)
+	}
+
+	out, err := e.Transform(d)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []float64{
+		0, 1, 0,
+		1, 0, 1,
+		2, 1, 0,
+		3, 0, 0,
+	}
+
+	for i, v := range expected {
+		if out.Matrix.Data[i] != v {
+			t.Fatalf("expected %v, got %v", expected, out.Matrix.Data)
+		}
+	}
+}
+
+func TestOneHotEncoderConcurrent(t *testing.T) {
+	d := newTable(t, irisSchema, [][]any{{0.0, "Setosa"}, {1.0, "Virginica"}})
+	e := &OneHotEncoder{Col: "variety", Categories: []string{"Setosa", "Virginica"}}
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := e.Transform(d)
+
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if e.index != nil {
+		t.Errorf("expected Transform to leave the encoder unchanged")
+	}
+}
@@ -0,0 +1,413 @@
+package preprocess
+
+import (
+	"errors"
+	"fmt"
+	"gonn/datatable"
+	"gonn/vector"
+	"math"
+	"sort"
+	"strconv"
+)
+
+type Strategy uint8
+
+const (
+	Mean Strategy = iota + 1
+	Median
+	Mode
+	Constant
+	ForwardFill
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case Mean:
+		return "mean"
+	case Median:
+		return "median"
+	case Mode:
+		return "mode"
+	case Constant:
+		return "constant"
+	case ForwardFill:
+		return "ffill"
+	default:
+		return fmt.Sprintf("Strategy(%d)", uint8(s))
+	}
+}
+
+// Imputer fills missing values. Mean and Median fill numeric columns with
+// a fitted statistic and turn them into Float columns; Mode and Constant
+// keep the column type and also work on String and Categorical columns,
+// which are filled by label. ForwardFill repeats the previous value of the
+// same column and fills leading gaps with the first value present.
+type Imputer struct {
+	Strategy Strategy
+	Cols     []string
+
+	// Fill holds the fitted value of each column: a float64, or a string
+	// label for String and Categorical columns
+	Fill []any
+
+	// Value is the fill of a Constant imputer
+	Value any
+}
+
+func NewImputer(strategy Strategy) *Imputer {
+	return &Imputer{Strategy: strategy}
+}
+
+// NewConstantImputer fills every missing cell with v, a number or a label.
+func NewConstantImputer(v any) *Imputer {
+	return &Imputer{Strategy: Constant, Value: v}
+}
+
+func (im *Imputer) Fit(d *datatable.DataTable, cols ...string) error {
+	if len(cols) == 0 {
+		return errors.New("imputer fit requires at least one column")
+	}
+
+	fill := make([]any, len(cols))
+
+	for i, name := range cols {
+		typ, err := d.ColumnType(name)
+
+		if err != nil {
+			return fmt.Errorf("failed to fit %s imputer: %w", im.Strategy, err)
+		}
+
+		fill[i], err = im.fitColumn(d, name, typ)
+
+		if err != nil {
+			return fmt.Errorf("failed to fit %s imputer on column %s: %w", im.Strategy, name, err)
+		}
+	}
+
+	im.Cols = append([]string(nil), cols...)
+	im.Fill = fill
+
+	return nil
+}
+
+func (im *Imputer) fitColumn(d *datatable.DataTable, name string, typ datatable.ColumnType) (any, error) {
+	labeled := typ == datatable.String || typ == datatable.Categorical
+
+	switch im.Strategy {
+	case Mean, Median:
+		if labeled {
+			return nil, fmt.Errorf("column has type %s", typ)
+		}
+
+		v, err := d.Column(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		v = dropNaN(v)
+
+		if len(v) == 0 {
+			return nil, errors.New("column has no values")
+		}
+
+		if im.Strategy == Median {
+			sort.Float64s(v)
+
+			return datatable.Quantile(v, 0.5), nil
+		}
+
+		return vector.Mean(v)
+	case Mode:
+		labels, missing, err := columnLabels(d, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		counts := make(map[string]int)
+		best, bestCount := "", 0
+
+		// Ties go to the value seen first
+		for i, l := range labels {
+			if missing[i] {
+				continue
+			}
+
+			counts[l]++
+
+			if counts[l] > bestCount {
+				best, bestCount = l, counts[l]
+			}
+		}
+
+		if bestCount == 0 {
+			return nil, errors.New("column has no values")
+		}
+
+		if labeled {
+			return best, nil
+		}
+
+		return strconv.ParseFloat(best, 64)
+	case Constant:
+		if im.Value == nil {
+			return nil, errors.New("constant imputer has no value")
+		}
+
+		return im.Value, nil
+	case ForwardFill:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %d", im.Strategy)
+	}
+}
+
+// Transform returns a copy of d with the missing cells of the fitted
+// columns filled.
+func (im *Imputer) Transform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	if im.Cols == nil {
+		return nil, fmt.Errorf("%s imputer has not been fit", im.Strategy)
+	}
+
+	out, err := d.Select(d.Cols...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy datatable: %w", err)
+	}
+
+	for i, name := range im.Cols {
+		v, err := out.Column(name)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to impute: %w", err)
+		}
+
+		switch im.Strategy {
+		case Mean, Median:
+			for row, x := range v {
+				if math.IsNaN(x) {
+					v[row] = im.Fill[i].(float64)
+				}
+			}
+
+			err = out.SetColumn(name, v)
+		case ForwardFill:
+			err = forwardFill(out, name, v)
+		default:
+			for row, x := range v {
+				if math.IsNaN(x) && err == nil {
+					err = out.SetAt(row, name, im.Fill[i])
+				}
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to impute column %s: %w", name, err)
+		}
+	}
+
+	return out, nil
+}
+
+func forwardFill(d *datatable.DataTable, name string, v []float64) error {
+	prev := math.NaN()
+
+	for _, x := range v {
+		if !math.IsNaN(x) {
+			prev = x
+			break
+		}
+	}
+
+	for row, x := range v {
+		if !math.IsNaN(x) {
+			prev = x
+			continue
+		}
+
+		// Stored values are written back as is, so level codes stay valid
+		err := d.SetAt(row, name, prev)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// KNNImputer fills each missing cell with the mean of that column over the
+// K fitted rows nearest to the row, measured by Euclidean distance over the
+// columns both rows have, scaled up for the columns they do not.
+type KNNImputer struct {
+	K    int
+	Cols []string
+
+	// rows holds the fitted values of Cols, one row per fitted table row
+	rows [][]float64
+}
+
+func NewKNNImputer(k int) *KNNImputer {
+	return &KNNImputer{K: k}
+}
+
+func (im *KNNImputer) Fit(d *datatable.DataTable, cols ...string) error {
+	if im.K < 1 {
+		return fmt.Errorf("failed to fit knn imputer: k must be positive, got %d", im.K)
+	}
+
+	if len(cols) == 0 {
+		return errors.New("imputer fit requires at least one column")
+	}
+
+	values, err := numericColumns(d, cols)
+
+	if err != nil {
+		return fmt.Errorf("failed to fit knn imputer: %w", err)
+	}
+
+	im.Cols = append([]string(nil), cols...)
+	im.rows = values
+
+	return nil
+}
+
+func (im *KNNImputer) Transform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	if im.Cols == nil {
+		return nil, errors.New("knn imputer has not been fit")
+	}
+
+	values, err := numericColumns(d, im.Cols)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to impute: %w", err)
+	}
+
+	type neighbor struct {
+		dist float64
+		row  []float64
+	}
+
+	neighbors := make([]neighbor, 0, len(im.rows))
+
+	for _, x := range values {
+		if !hasNaN(x) {
+			continue
+		}
+
+		neighbors = neighbors[:0]
+
+		for _, r := range im.rows {
+			dist, ok := nanDistance(x, r)
+
+			if ok {
+				neighbors = append(neighbors, neighbor{dist, r})
+			}
+		}
+
+		sort.SliceStable(neighbors, func(i, j int) bool {
+			return neighbors[i].dist < neighbors[j].dist
+		})
+
+		for c := range x {
+			if !math.IsNaN(x[c]) {
+				continue
+			}
+
+			sum, n := 0.0, 0
+
+			for _, nb := range neighbors {
+				if n == im.K {
+					break
+				}
+
+				if !math.IsNaN(nb.row[c]) {
+					sum += nb.row[c]
+					n++
+				}
+			}
+
+			if n > 0 {
+				x[c] = sum / float64(n)
+			}
+		}
+	}
+
+	out, err := d.Select(d.Cols...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy datatable: %w", err)
+	}
+
+	col := make([]float64, len(values))
+
+	for c, name := range im.Cols {
+		for row, x := range values {
+			col[row] = x[c]
+		}
+
+		err = out.SetColumn(name, col)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to impute column %s: %w", name, err)
+		}
+	}
+
+	return out, nil
+}
+
+// numericColumns returns the named columns of d as one slice per row.
+func numericColumns(d *datatable.DataTable, cols []string) ([][]float64, error) {
+	values := make([][]float64, d.Matrix.Rows)
+
+	for row := range values {
+		values[row] = make([]float64, len(cols))
+	}
+
+	for c, name := range cols {
+		v, err := numericColumn(d, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for row, x := range v {
+			values[row][c] = x
+		}
+	}
+
+	return values, nil
+}
+
+// nanDistance is the Euclidean distance over the coordinates present in
+// both a and b, scaled by the fraction present. It reports false when
+// there are none.
+func nanDistance(a, b []float64) (float64, bool) {
+	sum, n := 0.0, 0
+
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			continue
+		}
+
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+		n++
+	}
+
+	if n == 0 {
+		return 0, false
+	}
+
+	return math.Sqrt(sum * float64(len(a)) / float64(n)), true
+}
+
+func hasNaN(v []float64) bool {
+	for _, x := range v {
+		if math.IsNaN(x) {
+			return true
+		}
+	}
+
+	return false
+}
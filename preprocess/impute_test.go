@@ -0,0 +1,116 @@
+package preprocess
+
+import (
+	"gonn/datatable"
+	"math"
+	"testing"
+)
+
+// missingSchema and missingRows hold a missing value in every column.
+var missingSchema = datatable.Schema{
+	{Name: "x", Type: datatable.Float},
+	{Name: "n", Type: datatable.Int},
+	{Name: "variety", Type: datatable.Categorical},
+}
+
+var missingRows = [][]any{
+	{nil, 1, "a"},
+	{1.0, nil, "b"},
+	{2.0, 3, nil},
+	{nil, 3, "b"},
+	{9.0, 5, "b"},
+}
+
+func TestImputer(t *testing.T) {
+	nan := math.NaN()
+
+	tests := []struct {
+		im  *Imputer
+		col string
+		exp []float64
+	}{
+		{NewImputer(Mean), "x", []float64{4, 1, 2, 4, 9}},
+		{NewImputer(Median), "x", []float64{2, 1, 2, 2, 9}},
+		{NewImputer(Mode), "n", []float64{1, 3, 3, 3, 5}},
+		{NewConstantImputer(-1.0), "x", []float64{-1, 1, 2, -1, 9}},
+		{NewImputer(ForwardFill), "x", []float64{1, 1, 2, 2, 9}},
+		{NewImputer(ForwardFill), "n", []float64{1, 1, 3, 3, 5}},
+	}
+
+	for _, tt := range tests {
+		d := newTable(t, missingSchema, missingRows)
+
+		err := tt.im.Fit(d, tt.col)
+
+		if err != nil {
+			t.Fatalf("expected no error fitting %s, got %v", tt.im.Strategy, err)
+		}
+
+		out, err := tt.im.Transform(d)
+
+		if err != nil {
+			t.Fatalf("expected no error imputing %s, got %v", tt.im.Strategy, err)
+		}
+
+		assertColumn(t, out, tt.col, tt.exp)
+	}
+
+	// The source table keeps its missing values
+	d := newTable(t, missingSchema, missingRows)
+	assertColumn(t, d, "x", []float64{nan, 1, 2, nan, 9})
+}
+
+func TestImputerLabels(t *testing.T) {
+	d := newTable(t, missingSchema, missingRows)
+
+	for _, im := range []*Imputer{NewImputer(Mode), NewConstantImputer("b")} {
+		err := im.Fit(d, "variety")
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		out, err := im.Transform(d)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		s, err := out.StringAt(2, "variety")
+
+		if err != nil || s != "b" {
+			t.Errorf("expected %s imputed variety %v, got %v (%v)", im.Strategy, "b", s, err)
+		}
+	}
+
+	err := NewImputer(Mean).Fit(d, "variety")
+
+	if err == nil {
+		t.Errorf("expected error for mean of categorical column, got nil")
+	}
+}
+
+func TestKNNImputer(t *testing.T) {
+	d := datatable.NewDataTable([]string{"a", "b"})
+
+	for _, r := range [][]float64{{1, 10}, {2, 20}, {3, 30}, {100, 1000}, {2.1, math.NaN()}} {
+		_ = d.AddRow(r)
+	}
+
+	im := NewKNNImputer(2)
+
+	err := im.Fit(d, "a", "b")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, err := im.Transform(d)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Nearest rows with b present are a=2 and a=3
+	assertColumn(t, out, "b", []float64{10, 20, 30, 1000, 25})
+}
@@ -0,0 +1,96 @@
+package preprocess
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"gonn/internal/binio"
+	"io"
+)
+
+var scalerMagic = [4]byte{'G', 'N', 'S', 'C'}
+
+const persistVersion = 1
+
+// Save writes the fitted scaler so the same scaling can be applied at
+// inference with LoadScaler.
+func (s *Scaler) Save(w io.Writer) error {
+	if s.Cols == nil {
+		return fmt.Errorf("%s scaler has not been fit", s.Kind)
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := binio.Encoder{W: bw}
+
+	enc.Write(scalerMagic)
+	enc.Write(uint8(persistVersion))
+	enc.Write(uint8(s.Kind))
+	enc.Write(s.Lo)
+	enc.Write(s.Hi)
+	enc.Write(s.Offset)
+	enc.Strings(s.Cols)
+	enc.Write(s.Center)
+	enc.Write(s.Scale)
+
+	if enc.Err != nil {
+		return fmt.Errorf("failed to save scaler: %w", enc.Err)
+	}
+
+	err := bw.Flush()
+
+	if err != nil {
+		return fmt.Errorf("failed to save scaler: %w", err)
+	}
+
+	return nil
+}
+
+func LoadScaler(r io.Reader) (*Scaler, error) {
+	dec := binio.Decoder{R: bufio.NewReader(r)}
+
+	var magic [4]byte
+	var version, kind uint8
+
+	dec.Read(&magic)
+	dec.Read(&version)
+
+	if dec.Err != nil {
+		return nil, fmt.Errorf("failed to load scaler header: %w", dec.Err)
+	}
+
+	if magic != scalerMagic {
+		return nil, errors.New("failed to load scaler: not a gonn scaler file")
+	}
+
+	if version != persistVersion {
+		return nil, fmt.Errorf("failed to load scaler: unsupported version %d", version)
+	}
+
+	s := Scaler{}
+
+	dec.Read(&kind)
+	dec.Read(&s.Lo)
+	dec.Read(&s.Hi)
+	dec.Read(&s.Offset)
+	s.Kind = Kind(kind)
+	s.Cols = dec.Strings()
+
+	if dec.Err != nil {
+		return nil, fmt.Errorf("failed to load scaler: %w", dec.Err)
+	}
+
+	s.Center = make([]float64, len(s.Cols))
+	s.Scale = make([]float64, len(s.Cols))
+	dec.Read(s.Center)
+	dec.Read(s.Scale)
+
+	if dec.Err != nil {
+		return nil, fmt.Errorf("failed to load scaler: %w", dec.Err)
+	}
+
+	if s.Kind < Standard || s.Kind > Log {
+		return nil, fmt.Errorf("failed to load scaler: unknown kind %d", kind)
+	}
+
+	return &s, nil
+}
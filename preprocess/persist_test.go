@@ -0,0 +1,48 @@
+package preprocess
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSaveLoadScaler(t *testing.T) {
+	d := newTable(t, scalerSchema, scalerRows)
+	s := NewMinMaxScaler(-1, 1)
+
+	err := s.Fit(d, "a", "b")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = s.Save(&buf)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	loaded, err := LoadScaler(&buf)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, col := range []string{"a", "b"} {
+		for _, x := range []float64{-3, 0, 7} {
+			exp, _ := s.TransformValue(col, x)
+			got, err := loaded.TransformValue(col, x)
+
+			if err != nil || got != exp {
+				t.Errorf("expected %v for %s %v, got %v (%v)", exp, col, x, got, err)
+			}
+		}
+	}
+
+	_, err = LoadScaler(bytes.NewReader([]byte("GONN")))
+
+	if err == nil {
+		t.Errorf("expected error for wrong magic, got nil")
+	}
+}
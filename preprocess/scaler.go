@@ -0,0 +1,283 @@
+package preprocess
+
+import (
+	"errors"
+	"fmt"
+	"gonn/datatable"
+	"gonn/vector"
+	"math"
+	"sort"
+	"strings"
+)
+
+type Kind uint8
+
+const (
+	Standard Kind = iota + 1
+	MinMax
+	Robust
+	MaxAbs
+	Log
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Standard:
+		return "standard"
+	case MinMax:
+		return "minmax"
+	case Robust:
+		return "robust"
+	case MaxAbs:
+		return "maxabs"
+	case Log:
+		return "log"
+	default:
+		return fmt.Sprintf("Kind(%d)", uint8(k))
+	}
+}
+
+// Scaler rescales numeric columns. Every kind except Log is affine and maps
+// x to (x - Center) / Scale; Log maps x to ln(x + Offset). NaN values are
+// ignored by Fit and pass through Transform unchanged.
+type Scaler struct {
+	Kind   Kind
+	Cols   []string
+	Center []float64
+	Scale  []float64
+
+	// Lo and Hi are the output range of a MinMax scaler
+	Lo, Hi float64
+
+	// Offset is added before taking the log of a Log transform
+	Offset float64
+}
+
+// NewStandardScaler centers on the mean and divides by the population
+// standard deviation.
+func NewStandardScaler() *Scaler {
+	return &Scaler{Kind: Standard}
+}
+
+// NewMinMaxScaler maps the fitted minimum and maximum onto [lo, hi].
+func NewMinMaxScaler(lo, hi float64) *Scaler {
+	return &Scaler{Kind: MinMax, Lo: lo, Hi: hi}
+}
+
+// NewRobustScaler centers on the median and divides by the interquartile
+// range, so outliers have little effect on the fit.
+func NewRobustScaler() *Scaler {
+	return &Scaler{Kind: Robust}
+}
+
+// NewMaxAbsScaler divides by the largest absolute value, keeping zeros and
+// signs intact.
+func NewMaxAbsScaler() *Scaler {
+	return &Scaler{Kind: MaxAbs}
+}
+
+// NewLogTransform takes ln(x + offset); use an offset of 1 for log1p.
+func NewLogTransform(offset float64) *Scaler {
+	return &Scaler{Kind: Log, Offset: offset}
+}
+
+// Fit learns the scaling of the named columns from d.
+func (s *Scaler) Fit(d *datatable.DataTable, cols ...string) error {
+	if len(cols) == 0 {
+		return errors.New("scaler fit requires at least one column")
+	}
+
+	if s.Kind == MinMax && !(s.Hi > s.Lo) {
+		return fmt.Errorf("minmax scaler requires lo < hi, got [%v, %v]", s.Lo, s.Hi)
+	}
+
+	center := make([]float64, len(cols))
+	scale := make([]float64, len(cols))
+
+	for i, name := range cols {
+		v, err := numericColumn(d, name)
+
+		if err != nil {
+			return fmt.Errorf("failed to fit %s scaler: %w", s.Kind, err)
+		}
+
+		v = dropNaN(v)
+
+		if len(v) == 0 {
+			return fmt.Errorf("failed to fit %s scaler: column %s has no values", s.Kind, name)
+		}
+
+		center[i], scale[i], err = s.fitColumn(v)
+
+		if err != nil {
+			return fmt.Errorf("failed to fit %s scaler on column %s: %w", s.Kind, name, err)
+		}
+
+		// Constant columns are only centered
+		if scale[i] == 0 {
+			scale[i] = 1
+		}
+	}
+
+	s.Cols = append([]string(nil), cols...)
+	s.Center = center
+	s.Scale = scale
+
+	return nil
+}
+
+func (s *Scaler) fitColumn(v []float64) (center, scale float64, err error) {
+	switch s.Kind {
+	case Standard:
+		center, err = vector.Mean(v)
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		variance, _ := vector.Variance(v)
+
+		return center, math.Sqrt(variance), nil
+	case MinMax:
+		lo, hi := v[0], v[0]
+
+		for _, x := range v {
+			lo = min(lo, x)
+			hi = max(hi, x)
+		}
+
+		scale = (hi - lo) / (s.Hi - s.Lo)
+
+		return lo - s.Lo*scale, scale, nil
+	case Robust:
+		sort.Float64s(v)
+
+		return datatable.Quantile(v, 0.5), datatable.Quantile(v, 0.75) - datatable.Quantile(v, 0.25), nil
+	case MaxAbs:
+		return 0, vector.InfNorm(v), nil
+	case Log:
+		for _, x := range v {
+			if !(x+s.Offset > 0) {
+				return 0, 0, fmt.Errorf("value %v plus offset %v is not positive", x, s.Offset)
+			}
+		}
+
+		return 0, 1, nil
+	default:
+		return 0, 0, fmt.Errorf("unknown scaler kind %d", s.Kind)
+	}
+}
+
+// Transform returns a copy of d with the fitted columns scaled.
+func (s *Scaler) Transform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	return s.apply(d, s.TransformValue)
+}
+
+// InverseTransform returns a copy of d with the fitted columns mapped back
+// to their original scale, e.g. to read predictions of a scaled target.
+func (s *Scaler) InverseTransform(d *datatable.DataTable) (*datatable.DataTable, error) {
+	return s.apply(d, s.InverseValue)
+}
+
+func (s *Scaler) apply(d *datatable.DataTable, fn func(col string, x float64) (float64, error)) (*datatable.DataTable, error) {
+	if s.Cols == nil {
+		return nil, fmt.Errorf("%s scaler has not been fit", s.Kind)
+	}
+
+	out, err := d.Select(d.Cols...)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy datatable: %w", err)
+	}
+
+	for _, name := range s.Cols {
+		v, err := numericColumn(out, name)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s scaler: %w", s.Kind, err)
+		}
+
+		for i, x := range v {
+			v[i], err = fn(name, x)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = out.SetColumn(name, v)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s scaler: %w", s.Kind, err)
+		}
+	}
+
+	return out, nil
+}
+
+// TransformValue scales a single value of a fitted column, for use on
+// model inputs at inference.
+func (s *Scaler) TransformValue(col string, x float64) (float64, error) {
+	i, err := s.colIdx(col)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if s.Kind == Log {
+		return math.Log(x + s.Offset), nil
+	}
+
+	return (x - s.Center[i]) / s.Scale[i], nil
+}
+
+// InverseValue undoes TransformValue, for use on model outputs.
+func (s *Scaler) InverseValue(col string, x float64) (float64, error) {
+	i, err := s.colIdx(col)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if s.Kind == Log {
+		return math.Exp(x) - s.Offset, nil
+	}
+
+	return x*s.Scale[i] + s.Center[i], nil
+}
+
+func (s *Scaler) colIdx(col string) (int, error) {
+	for i, name := range s.Cols {
+		if strings.EqualFold(name, col) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%s scaler was not fit on column %s", s.Kind, col)
+}
+
+func numericColumn(d *datatable.DataTable, name string) ([]float64, error) {
+	typ, err := d.ColumnType(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if typ == datatable.String || typ == datatable.Categorical {
+		return nil, fmt.Errorf("column %s has type %s and cannot be scaled", name, typ)
+	}
+
+	return d.Column(name)
+}
+
+func dropNaN(v []float64) []float64 {
+	out := v[:0]
+
+	for _, x := range v {
+		if !math.IsNaN(x) {
+			out = append(out, x)
+		}
+	}
+
+	return out
+}
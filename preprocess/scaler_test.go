@@ -0,0 +1,144 @@
+package preprocess
+
+import (
+	"gonn/datatable"
+	"math"
+	"testing"
+)
+
+// newTable builds a table with the given schema from rows of Go values, as
+// AddRecord takes them.
+func newTable(t *testing.T, schema datatable.Schema, rows [][]any) *datatable.DataTable {
+	t.Helper()
+
+	d := datatable.NewDataTableWithSchema(schema)
+
+	for _, r := range rows {
+		err := d.AddRecord(r...)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	return d
+}
+
+// scalerSchema and scalerRows hold two Float columns, b with a missing
+// value.
+var scalerSchema = datatable.Schema{
+	{Name: "a", Type: datatable.Float},
+	{Name: "b", Type: datatable.Float},
+}
+
+var scalerRows = [][]any{{1.0, -4.0}, {2.0, 0.0}, {3.0, 2.0}, {4.0, nil}, {10.0, 1.0}}
+
+func assertColumn(t *testing.T, d *datatable.DataTable, name string, exp []float64) {
+	t.Helper()
+
+	v, err := d.Column(name)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for i := range exp {
+		if math.IsNaN(exp[i]) && math.IsNaN(v[i]) {
+			continue
+		}
+
+		if math.Abs(v[i]-exp[i]) > 1e-9 {
+			t.Fatalf("expected column %s %v, got %v", name, exp, v)
+		}
+	}
+}
+
+func TestScalers(t *testing.T) {
+	d := newTable(t, scalerSchema, scalerRows)
+
+	tests := []struct {
+		s   *Scaler
+		exp []float64
+	}{
+		{NewStandardScaler(), []float64{-3 / math.Sqrt(10), -2 / math.Sqrt(10), -1 / math.Sqrt(10), 0, 6 / math.Sqrt(10)}},
+		{NewMinMaxScaler(0, 1), []float64{0, 1.0 / 9, 2.0 / 9, 3.0 / 9, 1}},
+		{NewMinMaxScaler(-1, 1), []float64{-1, -7.0 / 9, -5.0 / 9, -3.0 / 9, 1}},
+		{NewRobustScaler(), []float64{-1, -0.5, 0, 0.5, 3.5}},
+		{NewMaxAbsScaler(), []float64{0.1, 0.2, 0.3, 0.4, 1}},
+		{NewLogTransform(0), []float64{0, math.Log(2), math.Log(3), math.Log(4), math.Log(10)}},
+	}
+
+	for _, tt := range tests {
+		err := tt.s.Fit(d, "a")
+
+		if err != nil {
+			t.Fatalf("expected no error fitting %s, got %v", tt.s.Kind, err)
+		}
+
+		out, err := tt.s.Transform(d)
+
+		if err != nil {
+			t.Fatalf("expected no error transforming %s, got %v", tt.s.Kind, err)
+		}
+
+		assertColumn(t, out, "a", tt.exp)
+
+		// Other columns and the source table are untouched
+		assertColumn(t, out, "b", []float64{-4, 0, 2, math.NaN(), 1})
+		assertColumn(t, d, "a", []float64{1, 2, 3, 4, 10})
+
+		back, err := tt.s.InverseTransform(out)
+
+		if err != nil {
+			t.Fatalf("expected no error inverting %s, got %v", tt.s.Kind, err)
+		}
+
+		assertColumn(t, back, "a", []float64{1, 2, 3, 4, 10})
+	}
+}
+
+func TestScalerNaN(t *testing.T) {
+	d := newTable(t, scalerSchema, scalerRows)
+	s := NewMaxAbsScaler()
+
+	err := s.Fit(d, "b")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	out, _ := s.Transform(d)
+
+	assertColumn(t, out, "b", []float64{-1, 0, 0.5, math.NaN(), 0.25})
+}
+
+func TestScalerError(t *testing.T) {
+	d := newTable(t, scalerSchema, scalerRows)
+
+	err := NewLogTransform(0).Fit(d, "b")
+
+	if err == nil {
+		t.Errorf("expected error for log of negative values, got nil")
+	}
+
+	err = NewMinMaxScaler(1, 0).Fit(d, "a")
+
+	if err == nil {
+		t.Errorf("expected error for empty minmax range, got nil")
+	}
+
+	_, err = NewStandardScaler().Transform(d)
+
+	if err == nil {
+		t.Errorf("expected error for unfit scaler, got nil")
+	}
+
+	s := NewStandardScaler()
+	_ = s.Fit(d, "a")
+
+	_, err = s.TransformValue("b", 1)
+
+	if err == nil {
+		t.Errorf("expected error for column the scaler was not fit on, got nil")
+	}
+}
@@ -1,11 +1,12 @@
 package csv
 
 import (
-	"encoding/csv"
 	"fmt"
 	"gonn/datatable"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 )
 
 type TableReader struct {
@@ -14,11 +15,39 @@ type TableReader struct {
 	ColDefs   []*ColumnDef
 	HasHeader bool
 	Separator rune
+
+	// NATokens are cell values read as missing instead of being parsed,
+	// compared after trimming spaces and ignoring case. None are set by
+	// default, so every cell reaches its column's ParseFn.
+	NATokens []string
+
+	// InferSchema types auto-registered columns from the first InferRows
+	// rows (100 when zero) instead of reading them all as Float. A column
+	// whose later cell does not fit its inferred type is widened, Int to
+	// Float and anything else to Categorical, rather than failing the row
+	InferSchema bool
+	InferRows   int
+
+	// Header holds the header line after a read when HasHeader is set
+	Header []string
+
+	// OnError is the policy for bad rows, and Report lists the rows and
+	// cells it skipped or replaced during the last read
+	OnError ErrorPolicy
+	Report  Report
 }
 
+// DefaultNATokens are common spellings of a missing value, for callers to
+// assign to NATokens.
+var DefaultNATokens = []string{"", "NA", "N/A", "NaN", "null", "None", "?"}
+
+// ColumnDef reads the cell at Idx, or under the header named Header when
+// it is set, into a table column called Name.
 type ColumnDef struct {
 	Name    string
 	Idx     int
+	Header  string
+	Type    datatable.ColumnType
 	ParseFn func(v *string) (float64, error)
 }
 
@@ -38,7 +67,43 @@ func (tr *TableReader) DefineColumn(idx int, name string, parseFn func(v *string
 	})
 }
 
-func (tr *TableReader) ReadTable() error {
+// DefineTypedColumn reads the cell at idx as a value of the given type
+// instead of through a parse function. String and Categorical cells are
+// kept as labels in the table rather than converted to floats up front.
+func (tr *TableReader) DefineTypedColumn(idx int, name string, typ datatable.ColumnType) {
+	tr.ColDefs = append(tr.ColDefs, &ColumnDef{
+		Name: name,
+		Idx:  idx,
+		Type: typ,
+	})
+}
+
+// DefineColumnByHeader reads the column under the given header name
+// through a parse function, naming the table column after the header.
+func (tr *TableReader) DefineColumnByHeader(header string, parseFn func(v *string) (float64, error)) {
+	tr.ColDefs = append(tr.ColDefs, &ColumnDef{
+		Name:    header,
+		Header:  header,
+		ParseFn: parseFn,
+	})
+}
+
+// DefineTypedColumnByHeader reads the column under the given header name
+// as a value of the given type.
+func (tr *TableReader) DefineTypedColumnByHeader(header string, typ datatable.ColumnType) {
+	tr.ColDefs = append(tr.ColDefs, &ColumnDef{
+		Name:   header,
+		Header: header,
+		Type:   typ,
+	})
+}
+
+// ReadTable reads the file into DataTable. When no columns are defined,
+// every column is registered under its header name ("col<i>" without a
+// header) as a Float column, or with an inferred type if InferSchema is
+// set; the registered definitions are left in ColDefs. Bad rows are
+// handled by OnError, and with FailFast the error is a *ParseError.
+func (tr *TableReader) ReadTable() (err error) {
 	f, err := os.Open(tr.Path)
 
 	if err != nil {
@@ -46,74 +111,183 @@ func (tr *TableReader) ReadTable() error {
 	}
 
 	defer func(f *os.File) {
-		err := f.Close()
-		if err != nil {
-			panic("failed to close file")
+		closeErr := f.Close()
+		if closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close file path: %s, with error: %w", tr.Path, closeErr)
 		}
 	}(f)
 
-	funcLen := len(tr.ColDefs)
+	return tr.ReadTableFrom(f)
+}
 
-	r := csv.NewReader(f)
-	r.Comma = tr.Separator
+// ReadTableFrom reads a whole table from r into DataTable as ReadTable
+// does, e.g. from stdin or an HTTP body. Compressed input is detected as
+// in Stream.
+func (tr *TableReader) ReadTableFrom(r io.Reader) error {
+	tr.Report = Report{}
 
-	tr.DataTable = datatable.NewDataTable([]string{})
+	s, err := tr.Stream(r)
 
-	if tr.HasHeader {
-		_, err := r.Read()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = s.Close()
+		tr.Report = s.Report()
+	}()
+
+	table := datatable.NewDataTableWithSchema(s.Schema())
+
+	for s.Next() {
+		err = s.convert(table)
 
 		if err != nil {
-			return fmt.Errorf("failed to read file header, path: %s, error: %w", tr.Path, err)
+			return err
 		}
 
-		for _, def := range tr.ColDefs {
-			err := tr.DataTable.AddColumn(def.Name, []float64{})
-			if err != nil {
-				return fmt.Errorf("failed to add column %s, error: %w", def.Name, err)
-			}
+		err = table.AddRecord(s.Row()...)
+
+		if err != nil {
+			return fmt.Errorf("failed to add parsed row to datatable: %w", err)
 		}
 	}
 
-	lineNo := 1
+	if s.Err() != nil {
+		return s.Err()
+	}
+
+	tr.DataTable = table
 
-	for {
-		line, err := r.Read()
+	return nil
+}
+
+func (tr *TableReader) isNA(s string) bool {
+	s = strings.TrimSpace(s)
 
-		if err == io.EOF {
-			break
+	for _, token := range tr.NATokens {
+		if strings.EqualFold(s, token) {
+			return true
 		}
+	}
 
-		if err != nil {
-			panic("csv reader failed reading line")
+	return false
+}
+
+// resolveColumns looks up the index of the columns defined by header name.
+func (tr *TableReader) resolveColumns() ([]*ColumnDef, error) {
+	defs := make([]*ColumnDef, len(tr.ColDefs))
+
+	for i, def := range tr.ColDefs {
+		defs[i] = def
+
+		if def.Header == "" {
+			continue
 		}
 
-		row := make([]float64, funcLen)
-		lineLen := len(line)
+		if tr.Header == nil {
+			return nil, fmt.Errorf("column %s is defined by header name but the file has no header", def.Name)
+		}
 
-		for i, def := range tr.ColDefs {
-			if def.Idx >= lineLen {
-				return fmt.Errorf("column index %d out of bounds of parsed line values, got %d (line %d)",
-					def.Idx, len(line), lineNo)
+		idx := -1
+
+		for j, h := range tr.Header {
+			if strings.EqualFold(strings.TrimSpace(h), def.Header) {
+				idx = j
+				break
 			}
+		}
 
-			s := line[def.Idx]
-			v, err := def.ParseFn(&s)
+		if idx < 0 {
+			return nil, fmt.Errorf("failed to find column %s in file header", def.Header)
+		}
 
-			if err != nil {
-				return fmt.Errorf("failed to parse cell with column parse function")
-			}
+		resolved := *def
+		resolved.Idx = idx
+		defs[i] = &resolved
+	}
+
+	return defs, nil
+}
+
+func (tr *TableReader) registerColumns(sample [][]string) {
+	n := len(tr.Header)
+
+	if n == 0 && len(sample) > 0 {
+		n = len(sample[0])
+	}
 
-			row[i] = v
+	for i := range n {
+		name := "col" + strconv.Itoa(i)
+
+		if i < len(tr.Header) {
+			name = strings.TrimSpace(tr.Header[i])
 		}
 
-		err = tr.DataTable.AddRow(row)
+		typ := datatable.Float
 
-		if err != nil {
-			return fmt.Errorf("failed to add parsed row to datatable: %w", err)
+		if tr.InferSchema {
+			typ = tr.inferType(sample, i)
 		}
 
-		lineNo++
+		tr.DefineTypedColumn(i, name, typ)
 	}
+}
 
-	return nil
+func (tr *TableReader) inferRows() int {
+	if tr.InferRows > 0 {
+		return tr.InferRows
+	}
+
+	return 100
+}
+
+// inferType returns the narrowest type that every present sampled cell of
+// column idx parses as: Int, Float, Bool or Timestamp, else Categorical.
+func (tr *TableReader) inferType(sample [][]string, idx int) datatable.ColumnType {
+	candidates := []datatable.ColumnType{datatable.Int, datatable.Float, datatable.Bool, datatable.Timestamp}
+	ok := map[datatable.ColumnType]bool{}
+
+	for _, typ := range candidates {
+		ok[typ] = true
+	}
+
+	present := 0
+
+	for _, line := range sample {
+		if idx >= len(line) || tr.isNA(line[idx]) {
+			continue
+		}
+
+		s := strings.TrimSpace(line[idx])
+		present++
+
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			ok[datatable.Int] = false
+		}
+
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			ok[datatable.Float] = false
+		}
+
+		if _, err := strconv.ParseBool(s); err != nil {
+			ok[datatable.Bool] = false
+		}
+
+		if _, err := datatable.ParseTime(s); err != nil {
+			ok[datatable.Timestamp] = false
+		}
+	}
+
+	if present == 0 {
+		return datatable.Float
+	}
+
+	for _, typ := range candidates {
+		if ok[typ] {
+			return typ
+		}
+	}
+
+	return datatable.Categorical
 }
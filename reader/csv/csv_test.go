@@ -2,7 +2,11 @@ package csv
 
 import (
 	"errors"
+	"gonn/datatable"
+	"gonn/reader/parse"
 	"gonn/sample"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -194,3 +198,279 @@ func TestReadLambdaError(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestReadTyped(t *testing.T) {
+	filePath, err := sample.GetSampleFilePath("iris.csv")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := NewReader(filePath, true, ',')
+
+	tr.DefineTypedColumn(0, "sepal.length", datatable.Float)
+	tr.DefineColumn(1, "sepal.width", parse.Float)
+	tr.DefineTypedColumn(4, "variety", datatable.Categorical)
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	table := tr.DataTable
+
+	if table.Matrix.Rows != 150 {
+		t.Errorf("expected %d matrix rows, got %d", 150, table.Matrix.Rows)
+	}
+
+	v, err := table.StringAt(149, "variety")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if v != "Virginica" {
+		t.Errorf("expected %s, got %s", "Virginica", v)
+	}
+
+	levels, _ := table.Levels("variety")
+
+	if len(levels) != 3 {
+		t.Errorf("expected %d levels, got %d", 3, len(levels))
+	}
+}
+
+func TestReadMissing(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "missing.csv")
+	data := "a,b,c\n1,x,2\n,NA,3\n4,y,n/a\n-,z,5\n"
+
+	err := os.WriteFile(filePath, []byte(data), 0o644)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := NewReader(filePath, true, ',')
+	tr.NATokens = append(DefaultNATokens, "-")
+
+	tr.DefineColumn(0, "a", parse.Float)
+	tr.DefineTypedColumn(1, "b", datatable.Categorical)
+	tr.DefineTypedColumn(2, "c", datatable.Int)
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	counts := tr.DataTable.MissingCounts()
+
+	for col, exp := range map[string]int{"a": 2, "b": 1, "c": 1} {
+		if counts[col] != exp {
+			t.Errorf("expected %d missing values in column %s, got %d", exp, col, counts[col])
+		}
+	}
+
+	levels, _ := tr.DataTable.Levels("b")
+
+	if len(levels) != 3 {
+		t.Errorf("expected missing values to not become levels, got %v", levels)
+	}
+}
+
+func TestReadNADefault(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "labels.csv")
+
+	err := os.WriteFile(filePath, []byte("label\nNA\nNone\n"), 0o644)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := NewReader(filePath, true, ',')
+	tr.DefineColumn(0, "label", parse.NewLabelMap("NA", "None").Parse)
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if tr.DataTable.Matrix.Data[0] != 0 || tr.DataTable.Matrix.Data[1] != 1 {
+		t.Errorf("expected NA and None to be parsed as labels, got %v", tr.DataTable.Matrix.Data)
+	}
+}
+
+func TestReadByHeader(t *testing.T) {
+	filePath, err := sample.GetSampleFilePath("iris.csv")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := NewReader(filePath, true, ',')
+
+	tr.DefineTypedColumnByHeader("variety", datatable.Categorical)
+	tr.DefineColumnByHeader("petal.width", parse.Float)
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	table := tr.DataTable
+
+	if table.Cols[0] != "variety" || table.Cols[1] != "petal.width" {
+		t.Errorf("expected columns named after the headers, got %v", table.Cols)
+	}
+
+	v, err := table.FloatAt(0, "petal.width")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if v != 0.2 {
+		t.Errorf("expected %f, got %f", 0.2, v)
+	}
+
+	tr = NewReader(filePath, true, ',')
+	tr.DefineColumnByHeader("petal.depth", parse.Float)
+
+	err = tr.ReadTable()
+
+	if err == nil {
+		t.Error("expected error for unknown header, got nil")
+	}
+
+	tr = NewReader(filePath, false, ',')
+	tr.DefineColumnByHeader("variety", parse.Float)
+
+	err = tr.ReadTable()
+
+	if err == nil {
+		t.Error("expected error for header lookup without a header, got nil")
+	}
+}
+
+func TestReadAutoRegister(t *testing.T) {
+	filePath, err := sample.GetSampleFilePath("winequality-red.csv")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := NewReader(filePath, true, ';')
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	table := tr.DataTable
+
+	if len(table.Cols) != 12 || table.Cols[11] != "quality" {
+		t.Errorf("expected 12 columns ending in quality, got %v", table.Cols)
+	}
+
+	if table.Matrix.Rows != 1599 {
+		t.Errorf("expected %d matrix rows, got %d", 1599, table.Matrix.Rows)
+	}
+
+	if len(tr.ColDefs) != 12 {
+		t.Errorf("expected %d registered column definitions, got %d", 12, len(tr.ColDefs))
+	}
+}
+
+func TestReadInferSchema(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "infer.csv")
+	data := "id,price,flag,when,label\n" +
+		"1,2.5,true,2024-01-02,a\n" +
+		"2,NA,false,2024-01-03 10:00:00,b\n" +
+		"3,4,true,2024-01-04,a\n"
+
+	err := os.WriteFile(filePath, []byte(data), 0o644)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := NewReader(filePath, true, ',')
+	tr.NATokens = DefaultNATokens
+	tr.InferSchema = true
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := []datatable.ColumnType{datatable.Int, datatable.Float, datatable.Bool, datatable.Timestamp, datatable.Categorical}
+
+	for i, f := range tr.DataTable.Schema() {
+		if f.Type != exp[i] {
+			t.Errorf("expected column %s to have type %s, got %s", f.Name, exp[i], f.Type)
+		}
+	}
+
+	if tr.DataTable.Matrix.Rows != 3 {
+		t.Errorf("expected %d matrix rows, got %d", 3, tr.DataTable.Matrix.Rows)
+	}
+
+	tr = NewReader(filePath, false, ',')
+	tr.InferSchema = true
+	tr.InferRows = 1
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if tr.DataTable.Cols[0] != "col0" || tr.DataTable.Matrix.Rows != 4 {
+		t.Errorf("expected generated names and 4 rows, got %v with %d rows", tr.DataTable.Cols, tr.DataTable.Matrix.Rows)
+	}
+}
+
+func TestReadInferWiden(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "widen.csv")
+	data := "count,flag\n" +
+		"1,true\n" +
+		"2,false\n" +
+		"2.5,yes\n"
+
+	err := os.WriteFile(filePath, []byte(data), 0o644)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := NewReader(filePath, true, ',')
+	tr.InferSchema = true
+	tr.InferRows = 1
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := []datatable.ColumnType{datatable.Float, datatable.Categorical}
+
+	for i, f := range tr.DataTable.Schema() {
+		if f.Type != exp[i] || tr.ColDefs[i].Type != exp[i] {
+			t.Errorf("expected column %s to have type %s, got %s", f.Name, exp[i], f.Type)
+		}
+	}
+
+	if v, err := tr.DataTable.FloatAt(2, "count"); err != nil || v != 2.5 {
+		t.Errorf("expected %v, got %v (%v)", 2.5, v, err)
+	}
+
+	if v, err := tr.DataTable.StringAt(0, "flag"); err != nil || v != "true" {
+		t.Errorf("expected %s, got %s (%v)", "true", v, err)
+	}
+}
@@ -0,0 +1,57 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorPolicy selects what a read does with a row that cannot be read.
+type ErrorPolicy uint8
+
+const (
+	// FailFast stops the read at the first bad row with its ParseError.
+	FailFast ErrorPolicy = iota
+	// SkipRow drops bad rows and records them in the Report.
+	SkipRow
+	// ReplaceMissing stores cells that fail to parse as missing values and
+	// records them in the Report. Lines that are not valid CSV are skipped.
+	ReplaceMissing
+)
+
+// ErrShortRow is the cause of a ParseError for a column index past the end
+// of the line.
+var ErrShortRow = errors.New("line has too few fields")
+
+// ParseError locates a cell, or a whole line when Column is empty, that
+// could not be read. Line is the line number in the input, counting the
+// header.
+type ParseError struct {
+	Line   int
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("line %d, column %s, value %q: %v", e.Line, e.Column, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Report lists the rows skipped and the cells replaced with missing values
+// during a read.
+type Report struct {
+	Skipped  []*ParseError
+	Replaced []*ParseError
+}
+
+// Clean reports whether the read needed no skips or replacements.
+func (r Report) Clean() bool {
+	return len(r.Skipped) == 0 && len(r.Replaced) == 0
+}
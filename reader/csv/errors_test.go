@@ -0,0 +1,116 @@
+package csv
+
+import (
+	"errors"
+	"gonn/datatable"
+	"gonn/reader/parse"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const badTable = "a,b,c\n1,x,2\n2,y,oops\n3,z\"z,3\n4,w,4\n5\n6,v,6\n"
+
+func newBadTableReader(policy ErrorPolicy) *TableReader {
+	tr := NewReader("", true, ',')
+	tr.OnError = policy
+
+	tr.DefineColumn(0, "a", parse.Float)
+	tr.DefineTypedColumn(1, "b", datatable.Categorical)
+	tr.DefineTypedColumn(2, "c", datatable.Int)
+
+	return tr
+}
+
+func TestReadFailFast(t *testing.T) {
+	tr := newBadTableReader(FailFast)
+
+	err := tr.ReadTableFrom(strings.NewReader(badTable))
+
+	var pe *ParseError
+
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+
+	if pe.Line != 3 || pe.Column != "c" || pe.Value != "oops" {
+		t.Errorf("expected line 3, column c, value oops, got %+v", pe)
+	}
+
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected the cause to be kept, got %v", pe.Err)
+	}
+
+	tr = NewReader("", false, ',')
+	tr.DefineColumn(0, "a", func(s *string) (float64, error) {
+		return 0, errors.New("test error")
+	})
+
+	err = tr.ReadTableFrom(strings.NewReader("1\n"))
+
+	if err == nil || !strings.Contains(err.Error(), "test error") {
+		t.Errorf("expected parse function error in %v", err)
+	}
+}
+
+func TestReadSkipRow(t *testing.T) {
+	tr := newBadTableReader(SkipRow)
+
+	err := tr.ReadTableFrom(strings.NewReader(badTable))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if tr.DataTable.Matrix.Rows != 3 {
+		t.Errorf("expected %d matrix rows, got %d", 3, tr.DataTable.Matrix.Rows)
+	}
+
+	skipped := tr.Report.Skipped
+
+	if len(skipped) != 3 || len(tr.Report.Replaced) != 0 {
+		t.Fatalf("expected 3 skipped rows and no replacements, got %+v", tr.Report)
+	}
+
+	if skipped[0].Line != 3 || skipped[0].Column != "c" {
+		t.Errorf("expected first skip at line 3 column c, got %+v", skipped[0])
+	}
+
+	if skipped[1].Line != 4 || skipped[1].Column != "" {
+		t.Errorf("expected malformed line 4 to be skipped, got %+v", skipped[1])
+	}
+
+	if !errors.Is(skipped[2], ErrShortRow) {
+		t.Errorf("expected short row error, got %v", skipped[2])
+	}
+}
+
+func TestReadReplaceMissing(t *testing.T) {
+	tr := newBadTableReader(ReplaceMissing)
+
+	err := tr.ReadTableFrom(strings.NewReader(badTable))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	table := tr.DataTable
+
+	if table.Matrix.Rows != 5 {
+		t.Errorf("expected %d matrix rows, got %d", 5, table.Matrix.Rows)
+	}
+
+	counts := table.MissingCounts()
+
+	if counts["a"] != 0 || counts["b"] != 1 || counts["c"] != 2 {
+		t.Errorf("expected missing counts 0, 1 and 2, got %v", counts)
+	}
+
+	if len(tr.Report.Skipped) != 1 || len(tr.Report.Replaced) != 3 {
+		t.Errorf("expected 1 skipped row and 3 replaced cells, got %+v", tr.Report)
+	}
+
+	if tr.Report.Clean() {
+		t.Error("expected report to not be clean")
+	}
+}
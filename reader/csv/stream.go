@@ -0,0 +1,468 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"gonn/datatable"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Stream reads the rows of a table one at a time, so input larger than
+// memory can be processed row by row or in fixed-size chunks, e.g. one
+// mini-batch per chunk:
+//
+//	for {
+//		chunk, err := s.ReadChunk(32)
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+type Stream struct {
+	r      *csv.Reader
+	tr     *TableReader
+	defs   []*ColumnDef
+	schema datatable.Schema
+	buf    *datatable.DataTable
+
+	// inferred marks the columns typed by InferSchema, which widen when a
+	// later cell does not fit, and widened is set until the tables being
+	// filled are converted to match
+	inferred []bool
+	widened  bool
+
+	closer io.Closer
+	sample []record
+	row    []any
+	report Report
+	err    error
+}
+
+type record struct {
+	line   int
+	fields []string
+}
+
+// Stream starts reading a table from r with the reader's column
+// definitions, header, NA and error policy settings; Path is not used.
+// Input compressed with gzip, bzip2 or zstd is decompressed transparently. The
+// header and any rows sampled to register columns are read before Stream
+// returns.
+func (tr *TableReader) Stream(r io.Reader) (*Stream, error) {
+	src, closer, err := decompress(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stream{
+		tr:     tr,
+		closer: closer,
+	}
+
+	s.r = csv.NewReader(src)
+	s.r.Comma = tr.Separator
+	s.r.FieldsPerRecord = -1
+
+	tr.Header = nil
+
+	if tr.HasHeader {
+		header, err := s.r.Read()
+
+		if err != nil {
+			_ = s.Close()
+			return nil, fmt.Errorf("failed to read header: %w", lineError(1, err))
+		}
+
+		tr.Header = header
+	}
+
+	// Auto-registration needs the column count and inference needs sample
+	// rows, so read ahead before resolving the columns
+	registered := len(tr.ColDefs) == 0
+
+	if registered {
+		n := 1
+		if tr.InferSchema {
+			n = tr.inferRows()
+		}
+
+		fields := make([][]string, 0, n)
+
+		for len(s.sample) < n {
+			rec, err := s.read()
+
+			if err == io.EOF {
+				break
+			}
+
+			if err != nil {
+				_ = s.Close()
+				return nil, err
+			}
+
+			s.sample = append(s.sample, rec)
+			fields = append(fields, rec.fields)
+		}
+
+		tr.registerColumns(fields)
+	}
+
+	s.defs, err = tr.resolveColumns()
+
+	if err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+
+	s.schema = make(datatable.Schema, len(s.defs))
+	s.inferred = make([]bool, len(s.defs))
+
+	for i, def := range s.defs {
+		s.schema[i] = datatable.Field{
+			Name: def.Name,
+			Type: def.Type,
+		}
+		s.inferred[i] = registered && tr.InferSchema
+	}
+
+	s.buf = datatable.NewDataTableWithSchema(s.schema)
+
+	return s, nil
+}
+
+// Schema returns the fields of the rows returned by Row. A column typed by
+// InferSchema widens when a later cell does not fit its type, Int to Float
+// and anything else to Categorical, so the schema can change as rows are
+// read; rows already returned keep their values.
+func (s *Stream) Schema() datatable.Schema {
+	return s.schema
+}
+
+// Next reads the next row, reporting false at the end of the input or on
+// an error, which Err then returns. Bad rows are handled by the reader's
+// ErrorPolicy.
+func (s *Stream) Next() bool {
+	for s.err == nil {
+		var rec record
+
+		if len(s.sample) > 0 {
+			rec, s.sample = s.sample[0], s.sample[1:]
+		} else {
+			var err error
+			rec, err = s.read()
+
+			if err == io.EOF {
+				return false
+			}
+
+			if err != nil {
+				s.err = err
+				return false
+			}
+		}
+
+		row, err := s.parse(rec)
+
+		if err == nil {
+			s.row = row
+			return true
+		}
+
+		if s.tr.OnError == FailFast {
+			s.err = err
+			return false
+		}
+
+		s.report.Skipped = append(s.report.Skipped, err)
+	}
+
+	return false
+}
+
+// Row returns the values of the row read by Next in the form taken by
+// DataTable.AddRecord, with nil for missing cells.
+func (s *Stream) Row() []any {
+	return s.row
+}
+
+// Err returns the error that stopped the stream, a *ParseError when a row
+// could not be read.
+func (s *Stream) Err() error {
+	return s.err
+}
+
+// Report returns the rows skipped and cells replaced so far.
+func (s *Stream) Report() Report {
+	return s.report
+}
+
+// ReadChunk reads up to n rows into a new table, returning io.EOF once no
+// rows remain. String and Categorical levels keep the same code in every
+// chunk of a stream. Chunks read before a column widens keep its earlier
+// type.
+func (s *Stream) ReadChunk(n int) (*datatable.DataTable, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("chunk size must be positive, got %d", n)
+	}
+
+	// Rows are added to one buffer table that keeps the levels seen so
+	// far, and each chunk copies the rows with a clone of its levels
+	m := s.buf.Matrix
+	m.Rows, m.Data = 0, m.Data[:0]
+
+	for m.Rows < n && s.Next() {
+		err := s.convert(s.buf)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.buf.AddRecord(s.row...)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to add parsed row to datatable: %w", err)
+		}
+	}
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if m.Rows == 0 {
+		return nil, io.EOF
+	}
+
+	return s.buf.Head(m.Rows), nil
+}
+
+// Close releases the decompressor, if any. It does not close the
+// underlying reader.
+func (s *Stream) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+
+	return s.closer.Close()
+}
+
+// read returns the next line of the input. Lines that are not valid CSV
+// are skipped into the report unless the policy is FailFast.
+func (s *Stream) read() (record, error) {
+	for {
+		fields, err := s.r.Read()
+
+		if err == io.EOF {
+			return record{}, err
+		}
+
+		if err != nil {
+			var pe *csv.ParseError
+
+			if !errors.As(err, &pe) {
+				return record{}, fmt.Errorf("failed to read input: %w", err)
+			}
+
+			e := lineError(pe.StartLine, pe.Err)
+
+			if s.tr.OnError == FailFast {
+				return record{}, e
+			}
+
+			s.report.Skipped = append(s.report.Skipped, e)
+			continue
+		}
+
+		line, _ := s.r.FieldPos(0)
+
+		return record{line: line, fields: fields}, nil
+	}
+}
+
+// parse converts the cells of a line to row values. Under ReplaceMissing a
+// bad cell becomes missing; otherwise the first one is returned.
+func (s *Stream) parse(rec record) ([]any, *ParseError) {
+	row := make([]any, len(s.defs))
+
+	for i, def := range s.defs {
+		v, err := s.cell(rec, def)
+
+		if err != nil && s.widen(i, rec) {
+			v, err = s.cell(rec, def)
+		}
+
+		if err == nil {
+			row[i] = v
+			continue
+		}
+
+		if s.tr.OnError != ReplaceMissing {
+			return nil, err
+		}
+
+		s.report.Replaced = append(s.report.Replaced, err)
+		row[i] = nil
+	}
+
+	return row, nil
+}
+
+// widen moves an inferred column whose cell in rec does not parse to the
+// next type that holds it: Int to Float, anything else to Categorical.
+func (s *Stream) widen(i int, rec record) bool {
+	def := s.defs[i]
+
+	if !s.inferred[i] || def.Idx >= len(rec.fields) || def.Type == datatable.Categorical {
+		return false
+	}
+
+	typ := datatable.Categorical
+
+	if def.Type == datatable.Int {
+		if _, err := parseTyped(datatable.Float, rec.fields[def.Idx]); err == nil {
+			typ = datatable.Float
+		}
+	}
+
+	def.Type = typ
+	s.schema[i].Type = typ
+	s.widened = true
+
+	return true
+}
+
+// convert changes the columns of d to the types of any columns widened
+// since the last call.
+func (s *Stream) convert(d *datatable.DataTable) error {
+	if !s.widened {
+		return nil
+	}
+
+	for _, f := range s.schema {
+		err := d.ConvertColumn(f.Name, f.Type)
+
+		if err != nil {
+			return fmt.Errorf("failed to widen column: %w", err)
+		}
+	}
+
+	s.widened = false
+
+	return nil
+}
+
+func (s *Stream) cell(rec record, def *ColumnDef) (any, *ParseError) {
+	if def.Idx >= len(rec.fields) {
+		return nil, &ParseError{
+			Line:   rec.line,
+			Column: def.Name,
+			Err:    fmt.Errorf("%w: column index %d, got %d fields", ErrShortRow, def.Idx, len(rec.fields)),
+		}
+	}
+
+	v := rec.fields[def.Idx]
+
+	if s.tr.isNA(v) {
+		return nil, nil
+	}
+
+	var parsed any
+	var err error
+
+	if def.ParseFn != nil {
+		parsed, err = def.ParseFn(&v)
+	} else {
+		parsed, err = parseTyped(def.Type, v)
+	}
+
+	if err != nil {
+		return nil, &ParseError{
+			Line:   rec.line,
+			Column: def.Name,
+			Value:  v,
+			Err:    err,
+		}
+	}
+
+	return parsed, nil
+}
+
+// parseTyped converts a cell of a typed column to the Go value stored by
+// DataTable.AddRecord, so bad cells are caught with their position.
+func parseTyped(typ datatable.ColumnType, s string) (any, error) {
+	t := strings.TrimSpace(s)
+
+	switch typ {
+	case datatable.Float:
+		return strconv.ParseFloat(t, 64)
+	case datatable.Int:
+		return strconv.ParseInt(t, 10, 64)
+	case datatable.Bool:
+		return strconv.ParseBool(t)
+	case datatable.Timestamp:
+		return datatable.ParseTime(t)
+	default:
+		return s, nil
+	}
+}
+
+func lineError(line int, err error) *ParseError {
+	var pe *csv.ParseError
+
+	if errors.As(err, &pe) {
+		line, err = pe.StartLine, pe.Err
+	}
+
+	return &ParseError{Line: line, Err: err}
+}
+
+// decompress wraps r in a decompressor chosen by the magic bytes at its
+// start.
+func decompress(r io.Reader) (io.Reader, io.Closer, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+
+	if err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open gzip input: %w", err)
+		}
+
+		return zr, zr, nil
+	case bytes.HasPrefix(magic, bzip2Magic) && len(magic) == 4 && magic[3] >= '1' && magic[3] <= '9':
+		return bzip2.NewReader(br), nil, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open zstd input: %w", err)
+		}
+
+		rc := zr.IOReadCloser()
+
+		return rc, rc, nil
+	}
+
+	return br, nil, nil
+}
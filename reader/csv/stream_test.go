@@ -0,0 +1,215 @@
+package csv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"gonn/datatable"
+	"gonn/reader/parse"
+	"gonn/sample"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// bzip2Table is "a,b\n1,x\n2,y\n" compressed with bzip2, which the standard
+// library can only decompress.
+var bzip2Table = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xbc, 0xc7,
+	0x28, 0x45, 0x00, 0x00, 0x04, 0x59, 0x80, 0x00, 0x10, 0x00, 0x04, 0x30,
+	0x00, 0x30, 0x00, 0x00, 0x60, 0x20, 0x00, 0x31, 0x0c, 0x08, 0x23, 0x41,
+	0x9a, 0x8e, 0x04, 0x22, 0x17, 0x8b, 0xb9, 0x22, 0x9c, 0x28, 0x48, 0x5e,
+	0x63, 0x94, 0x22, 0x80,
+}
+
+func TestStreamChunks(t *testing.T) {
+	filePath, err := sample.GetSampleFilePath("iris.csv")
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	f, err := os.Open(filePath)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	defer f.Close()
+
+	tr := NewReader("", true, ',')
+
+	tr.DefineColumn(0, "sepal.length", parse.Float)
+	tr.DefineColumn(3, "petal.width", parse.Float)
+	tr.DefineColumn(4, "variety", parse.NewLabelMap("Setosa", "Versicolor", "Virginica").Parse)
+
+	s, err := tr.Stream(f)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	defer s.Close()
+
+	sizes := make([]int, 0)
+
+	for {
+		chunk, err := s.ReadChunk(64)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		x, err := chunk.ToMatrix("sepal.length", "petal.width")
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if x.Cols != 2 {
+			t.Errorf("expected %d batch columns, got %d", 2, x.Cols)
+		}
+
+		sizes = append(sizes, chunk.Matrix.Rows)
+	}
+
+	if len(sizes) != 3 || sizes[0] != 64 || sizes[1] != 64 || sizes[2] != 22 {
+		t.Errorf("expected chunks of 64, 64 and 22 rows, got %v", sizes)
+	}
+}
+
+func TestStreamRows(t *testing.T) {
+	tr := NewReader("", true, ',')
+	tr.NATokens = DefaultNATokens
+	tr.InferSchema = true
+
+	s, err := tr.Stream(strings.NewReader("a,b\n1,x\nNA,y\n3,x\n"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	schema := s.Schema()
+
+	if schema[0].Type != datatable.Int || schema[1].Type != datatable.Categorical {
+		t.Errorf("expected Int and Categorical columns, got %v", schema)
+	}
+
+	rows := 0
+	missing := 0
+
+	for s.Next() {
+		if s.Row()[0] == nil {
+			missing++
+		}
+
+		rows++
+	}
+
+	if s.Err() != nil {
+		t.Fatalf("expected no error, got %v", s.Err())
+	}
+
+	if rows != 3 || missing != 1 {
+		t.Errorf("expected 3 rows with 1 missing cell, got %d rows with %d", rows, missing)
+	}
+}
+
+func TestStreamParseError(t *testing.T) {
+	tr := NewReader("", false, ',')
+	tr.DefineColumn(0, "a", parse.Float)
+
+	s, err := tr.Stream(strings.NewReader("1\nx\n3\n"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = s.ReadChunk(10)
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if s.Next() {
+		t.Error("expected stream to stop after an error")
+	}
+}
+
+func TestStreamChunkLevels(t *testing.T) {
+	tr := NewReader("", true, ',')
+	tr.DefineTypedColumnByHeader("label", datatable.Categorical)
+
+	s, err := tr.Stream(strings.NewReader("label\na\nb\nb\na\n"))
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = s.ReadChunk(2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	chunk, err := s.ReadChunk(2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	m, _ := chunk.ToMatrix("label")
+	levels, _ := chunk.Levels("label")
+
+	if m.Data[0] != 1 || m.Data[1] != 0 || levels[0] != "a" {
+		t.Errorf("expected codes [1 0] for levels [a b], got %v for %v", m.Data, levels)
+	}
+}
+
+func TestReadCompressed(t *testing.T) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte("a,b\n1,x\n2,y\n"))
+	_ = zw.Close()
+
+	zw2, _ := zstd.NewWriter(nil)
+	zstdTable := zw2.EncodeAll([]byte("a,b\n1,x\n2,y\n"), nil)
+	_ = zw2.Close()
+
+	for name, data := range map[string][]byte{"gzip": buf.Bytes(), "bzip2": bzip2Table, "zstd": zstdTable} {
+		tr := NewReader("", true, ',')
+		tr.DefineColumnByHeader("a", parse.Float)
+		tr.DefineTypedColumnByHeader("b", datatable.Categorical)
+
+		err := tr.ReadTableFrom(bytes.NewReader(data))
+
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+
+		v, err := tr.DataTable.StringAt(1, "b")
+
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+
+		if tr.DataTable.Matrix.Rows != 2 || v != "y" {
+			t.Errorf("%s: expected 2 rows ending in y, got %d rows ending in %s", name, tr.DataTable.Matrix.Rows, v)
+		}
+	}
+
+	tr := NewReader("", true, ',')
+
+	err := tr.ReadTableFrom(bytes.NewReader([]byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}))
+
+	if err == nil {
+		t.Error("expected error for truncated zstd input, got nil")
+	}
+}
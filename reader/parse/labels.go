@@ -0,0 +1,95 @@
+package parse
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// LabelMap assigns codes to string labels, 0 for the first label and one
+// more for each new label it parses, so a class column can be read without
+// listing its classes up front. Codes learned during a read are kept for
+// decoding predictions afterwards.
+type LabelMap struct {
+	mu     sync.Mutex
+	labels []string
+	codes  map[string]int
+}
+
+// NewLabelMap returns a map with the given labels already assigned codes
+// in order.
+func NewLabelMap(labels ...string) *LabelMap {
+	m := LabelMap{codes: make(map[string]int)}
+
+	for _, l := range labels {
+		m.code(l)
+	}
+
+	return &m
+}
+
+// Parse returns the code of a label, assigning the next code to labels not
+// seen before. Surrounding spaces are ignored.
+func (m *LabelMap) Parse(v *string) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return float64(m.code(strings.TrimSpace(*v))), nil
+}
+
+func (m *LabelMap) code(label string) int {
+	if m.codes == nil {
+		m.codes = make(map[string]int)
+	}
+
+	c, ok := m.codes[label]
+
+	if !ok {
+		c = len(m.labels)
+		m.codes[label] = c
+		m.labels = append(m.labels, label)
+	}
+
+	return c
+}
+
+// Labels returns the labels in order of their codes.
+func (m *LabelMap) Labels() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	labels := make([]string, len(m.labels))
+	copy(labels, m.labels)
+
+	return labels
+}
+
+// Mapping returns the code of every label.
+func (m *LabelMap) Mapping() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mapping := make(map[string]int, len(m.codes))
+
+	for l, c := range m.codes {
+		mapping[l] = c
+	}
+
+	return mapping
+}
+
+// Label returns the label of a code, rounding regression outputs to the
+// nearest one.
+func (m *LabelMap) Label(code float64) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx := int(math.Round(code))
+
+	if math.IsNaN(code) || idx < 0 || idx >= len(m.labels) {
+		return "", fmt.Errorf("code %v has no label", code)
+	}
+
+	return m.labels[idx], nil
+}
@@ -0,0 +1,71 @@
+package parse
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLabelMap(t *testing.T) {
+	m := NewLabelMap("Setosa", "Versicolor")
+
+	for in, exp := range map[string]float64{"Versicolor": 1, "Virginica": 2, " Setosa ": 0} {
+		s := in
+		v, err := m.Parse(&s)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if v != exp {
+			t.Errorf("expected %q to have code %v, got %v", in, exp, v)
+		}
+	}
+
+	labels := m.Labels()
+
+	if len(labels) != 3 || labels[2] != "Virginica" {
+		t.Errorf("expected labels in code order, got %v", labels)
+	}
+
+	if m.Mapping()["Virginica"] != 2 {
+		t.Errorf("expected Virginica to map to 2, got %v", m.Mapping())
+	}
+
+	l, err := m.Label(1.2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if l != "Versicolor" {
+		t.Errorf("expected %s, got %s", "Versicolor", l)
+	}
+
+	_, err = m.Label(3)
+
+	if err == nil {
+		t.Error("expected error for unknown code, got nil")
+	}
+}
+
+func TestLabelMapConcurrent(t *testing.T) {
+	var m LabelMap
+	var wg sync.WaitGroup
+
+	for i := range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			s := string(rune('a' + i%4))
+			_, _ = m.Parse(&s)
+		}()
+	}
+
+	wg.Wait()
+
+	if len(m.Labels()) != 4 {
+		t.Errorf("expected %d labels, got %d", 4, len(m.Labels()))
+	}
+}
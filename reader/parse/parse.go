@@ -0,0 +1,171 @@
+// Package parse provides ready-made cell parsers for csv.ColumnDef.ParseFn.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"gonn/datatable"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Fn is the signature of csv.ColumnDef.ParseFn.
+type Fn func(v *string) (float64, error)
+
+// Float parses a float with '.' as the decimal separator, ignoring
+// surrounding spaces.
+func Float(v *string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(*v), 64)
+}
+
+// LocaleFloat parses floats written with the given decimal and digit group
+// separators, e.g. LocaleFloat(',', '.') for "1.234,5". Group separators
+// are dropped wherever they appear.
+func LocaleFloat(decimal, group rune) Fn {
+	return func(v *string) (float64, error) {
+		return localeFloat(*v, decimal, group)
+	}
+}
+
+func localeFloat(s string, decimal, group rune) (float64, error) {
+	if decimal == group {
+		return 0, errors.New("decimal and group separators must differ")
+	}
+
+	var b strings.Builder
+
+	for _, r := range strings.TrimSpace(s) {
+		switch r {
+		case group:
+		case decimal:
+			b.WriteRune('.')
+		case '.':
+			// A '.' that is not the decimal separator would otherwise be
+			// read as one
+			return 0, fmt.Errorf("unexpected separator in %q", s)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return strconv.ParseFloat(b.String(), 64)
+}
+
+// Int parses a base 10 integer.
+func Int(v *string) (float64, error) {
+	i, err := strconv.ParseInt(strings.TrimSpace(*v), 10, 64)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(i), nil
+}
+
+// Bool parses yes/no, y/n, true/false, t/f, on/off and 1/0, ignoring case,
+// as 1 or 0.
+func Bool(v *string) (float64, error) {
+	switch strings.ToLower(strings.TrimSpace(*v)) {
+	case "yes", "y", "true", "t", "on", "1":
+		return 1, nil
+	case "no", "n", "false", "f", "off", "0":
+		return 0, nil
+	}
+
+	return 0, fmt.Errorf("cannot parse %q as a bool", *v)
+}
+
+// Percent parses "12.5%", with or without the sign, as the fraction 0.125.
+func Percent(v *string) (float64, error) {
+	s := strings.TrimSpace(*v)
+	s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return f / 100, nil
+}
+
+// Currency parses amounts such as "$1,234.50", "-€3" or "(12.00)" written
+// with the given separators. Currency symbols and letter codes around the
+// number are dropped, and parentheses mark a negative amount.
+func Currency(decimal, group rune) Fn {
+	return func(v *string) (float64, error) {
+		s := strings.TrimSpace(*v)
+		sign := 1.0
+
+		if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+			s = s[1 : len(s)-1]
+			sign = -1
+		}
+
+		s = strings.TrimFunc(s, func(r rune) bool {
+			return unicode.Is(unicode.Sc, r) || unicode.IsLetter(r) || unicode.IsSpace(r)
+		})
+
+		if strings.HasPrefix(s, "-") {
+			s = s[1:]
+			sign = -sign
+		}
+
+		s = strings.TrimLeftFunc(s, func(r rune) bool {
+			return unicode.Is(unicode.Sc, r) || unicode.IsSpace(r)
+		})
+
+		f, err := localeFloat(s, decimal, group)
+
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %q as an amount: %w", *v, err)
+		}
+
+		return sign * f, nil
+	}
+}
+
+// Unix parses times in the given layout, or in the layouts of Timestamp
+// columns when it is empty, to seconds since the Unix epoch.
+func Unix(layout string) Fn {
+	return func(v *string) (float64, error) {
+		t, err := parseTime(*v, layout)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return float64(t.UnixNano()) / 1e9, nil
+	}
+}
+
+// unixEpochOrdinal is the ordinal of 1970-01-01.
+const unixEpochOrdinal = 719163
+
+// Ordinal parses dates like Unix to their proleptic Gregorian ordinal,
+// where 0001-01-01 is day 1. The time of day is dropped.
+func Ordinal(layout string) Fn {
+	return func(v *string) (float64, error) {
+		t, err := parseTime(*v, layout)
+
+		if err != nil {
+			return 0, err
+		}
+
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+		return float64(day.Unix()/86400 + unixEpochOrdinal), nil
+	}
+}
+
+func parseTime(s, layout string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if layout == "" {
+		return datatable.ParseTime(s)
+	}
+
+	return time.Parse(layout, s)
+}
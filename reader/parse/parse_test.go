@@ -0,0 +1,77 @@
+package parse
+
+import (
+	"math"
+	"testing"
+)
+
+func check(t *testing.T, name string, fn Fn, cases map[string]float64, bad ...string) {
+	t.Helper()
+
+	for in, exp := range cases {
+		s := in
+		v, err := fn(&s)
+
+		if err != nil {
+			t.Errorf("%s(%q): expected no error, got %v", name, in, err)
+			continue
+		}
+
+		if math.Abs(v-exp) > 1e-9 {
+			t.Errorf("%s(%q): expected %v, got %v", name, in, exp, v)
+		}
+	}
+
+	for _, in := range bad {
+		s := in
+		_, err := fn(&s)
+
+		if err == nil {
+			t.Errorf("%s(%q): expected error, got nil", name, in)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	check(t, "Float", Float, map[string]float64{" 1.5 ": 1.5, ".2": 0.2, "-3e2": -300}, "1,5", "x")
+	check(t, "LocaleFloat", LocaleFloat(',', '.'), map[string]float64{"1.234,5": 1234.5, "-0,25": -0.25, "7": 7}, "1,2,3", "x")
+	check(t, "LocaleFloat", LocaleFloat(',', ' '), map[string]float64{"1 234,5": 1234.5}, "1.5")
+	check(t, "LocaleFloat", LocaleFloat('.', '.'), nil, "1.5")
+}
+
+func TestInt(t *testing.T) {
+	check(t, "Int", Int, map[string]float64{"42": 42, " -7 ": -7}, "1.5", "")
+}
+
+func TestBool(t *testing.T) {
+	check(t, "Bool", Bool, map[string]float64{"Yes": 1, "no": 0, "TRUE": 1, "f": 0, "1": 1, "off": 0}, "maybe", "2")
+}
+
+func TestPercent(t *testing.T) {
+	check(t, "Percent", Percent, map[string]float64{"12.5%": 0.125, "50": 0.5, "-3 %": -0.03}, "%", "x%")
+}
+
+func TestCurrency(t *testing.T) {
+	check(t, "Currency", Currency('.', ','), map[string]float64{
+		"$1,234.50": 1234.5,
+		"-€3":       -3,
+		"€-3":       -3,
+		"(12.00)":   -12,
+		"USD 99":    99,
+		"($1,000)":  -1000,
+	}, "$", "1.2.3")
+	check(t, "Currency", Currency(',', '.'), map[string]float64{"1.234,50 €": 1234.5}, "")
+}
+
+func TestTime(t *testing.T) {
+	check(t, "Unix", Unix(""), map[string]float64{
+		"1970-01-02":           86400,
+		"1970-01-01T00:00:01Z": 1,
+	}, "yesterday")
+	check(t, "Unix", Unix("02/01/2006"), map[string]float64{"02/01/1970": 86400}, "1970-01-02")
+	check(t, "Ordinal", Ordinal(""), map[string]float64{
+		"0001-01-01":          1,
+		"1970-01-01":          719163,
+		"2024-03-01 23:59:59": 738946,
+	}, "x")
+}
@@ -0,0 +1,57 @@
+package vector
+
+// Portable kernels. They are the fallback on architectures without an
+// assembly implementation (or with the purego build tag) and the reference
+// the assembly kernels are tested against. Four independent accumulators
+// let the compiler overlap the multiply-adds.
+
+func dotGeneric(x, y []float64) float64 {
+	y = y[:len(x)]
+	var s0, s1, s2, s3 float64
+	i := 0
+
+	for ; i+4 <= len(x); i += 4 {
+		s0 += x[i] * y[i]
+		s1 += x[i+1] * y[i+1]
+		s2 += x[i+2] * y[i+2]
+		s3 += x[i+3] * y[i+3]
+	}
+
+	for ; i < len(x); i++ {
+		s0 += x[i] * y[i]
+	}
+
+	return (s0 + s1) + (s2 + s3)
+}
+
+func axpyGeneric(alpha float64, x, y []float64) {
+	y = y[:len(x)]
+	i := 0
+
+	for ; i+4 <= len(x); i += 4 {
+		y[i] += alpha * x[i]
+		y[i+1] += alpha * x[i+1]
+		y[i+2] += alpha * x[i+2]
+		y[i+3] += alpha * x[i+3]
+	}
+
+	for ; i < len(x); i++ {
+		y[i] += alpha * x[i]
+	}
+}
+
+func scaleGeneric(alpha float64, x, dst []float64) {
+	dst = dst[:len(x)]
+	i := 0
+
+	for ; i+4 <= len(x); i += 4 {
+		dst[i] = alpha * x[i]
+		dst[i+1] = alpha * x[i+1]
+		dst[i+2] = alpha * x[i+2]
+		dst[i+3] = alpha * x[i+3]
+	}
+
+	for ; i < len(x); i++ {
+		dst[i] = alpha * x[i]
+	}
+}
@@ -0,0 +1,74 @@
+//go:build !purego
+
+package vector
+
+// useAVX2 reports whether the CPU and OS support AVX2 and FMA3.
+var useAVX2 = detectAVX2()
+
+func detectAVX2() bool {
+	maxID, _, _, _ := cpuid(0, 0)
+
+	if maxID < 7 {
+		return false
+	}
+
+	_, _, ecx1, _ := cpuid(1, 0)
+
+	hasFMA := ecx1&(1<<12) != 0
+	hasOSXSAVE := ecx1&(1<<27) != 0
+	hasAVX := ecx1&(1<<28) != 0
+
+	if !(hasFMA && hasOSXSAVE && hasAVX) {
+		return false
+	}
+
+	// The OS must save the XMM and YMM register state
+	xcr0, _ := xgetbv()
+
+	if xcr0&6 != 6 {
+		return false
+	}
+
+	_, ebx7, _, _ := cpuid(7, 0)
+
+	return ebx7&(1<<5) != 0
+}
+
+func dot(x, y []float64) float64 {
+	if useAVX2 {
+		return dotAVX2(x, y)
+	}
+
+	return dotGeneric(x, y)
+}
+
+func axpy(alpha float64, x, y []float64) {
+	if useAVX2 {
+		axpyAVX2(alpha, x, y)
+		return
+	}
+
+	axpyGeneric(alpha, x, y)
+}
+
+func scale(alpha float64, x, dst []float64) {
+	if useAVX2 {
+		scaleAVX2(alpha, x, dst)
+		return
+	}
+
+	scaleGeneric(alpha, x, dst)
+}
+
+//go:noescape
+func dotAVX2(x, y []float64) float64
+
+//go:noescape
+func axpyAVX2(alpha float64, x, y []float64)
+
+//go:noescape
+func scaleAVX2(alpha float64, x, dst []float64)
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
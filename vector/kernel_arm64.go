@@ -0,0 +1,27 @@
+//go:build !purego
+
+package vector
+
+// Advanced SIMD (NEON) is mandatory on arm64, so no feature detection is
+// needed.
+
+func dot(x, y []float64) float64 {
+	return dotNEON(x, y)
+}
+
+func axpy(alpha float64, x, y []float64) {
+	axpyNEON(alpha, x, y)
+}
+
+func scale(alpha float64, x, dst []float64) {
+	scaleNEON(alpha, x, dst)
+}
+
+//go:noescape
+func dotNEON(x, y []float64) float64
+
+//go:noescape
+func axpyNEON(alpha float64, x, y []float64)
+
+//go:noescape
+func scaleNEON(alpha float64, x, dst []float64)
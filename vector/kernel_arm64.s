@@ -0,0 +1,110 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// All kernels iterate len(x) elements; callers guarantee the other slice is
+// at least as long.
+
+// func dotNEON(x, y []float64) float64
+TEXT ·dotNEON(SB), NOSPLIT, $0-56
+	MOVD x_base+0(FP), R0
+	MOVD x_len+8(FP), R2
+	MOVD y_base+24(FP), R1
+	VEOR V0.B16, V0.B16, V0.B16
+	VEOR V1.B16, V1.B16, V1.B16
+	VEOR V2.B16, V2.B16, V2.B16
+	VEOR V3.B16, V3.B16, V3.B16
+
+dot_loop8:
+	CMP  $8, R2
+	BLT  dot_reduce
+	VLD1.P 64(R0), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VLD1.P 64(R1), [V16.D2, V17.D2, V18.D2, V19.D2]
+	VFMLA V4.D2, V16.D2, V0.D2
+	VFMLA V5.D2, V17.D2, V1.D2
+	VFMLA V6.D2, V18.D2, V2.D2
+	VFMLA V7.D2, V19.D2, V3.D2
+	SUB  $8, R2
+	B    dot_loop8
+
+dot_reduce:
+	VFADD V1.D2, V0.D2, V0.D2
+	VFADD V3.D2, V2.D2, V2.D2
+	VFADD V2.D2, V0.D2, V0.D2
+	VFADDP V0.D2, V0.D2, V0.D2
+
+dot_loop1:
+	CBZ  R2, dot_done
+	FMOVD.P 8(R0), F4
+	FMOVD.P 8(R1), F5
+	FMADDD F4, F0, F5, F0
+	SUB  $1, R2
+	B    dot_loop1
+
+dot_done:
+	FMOVD F0, ret+48(FP)
+	RET
+
+// func axpyNEON(alpha float64, x, y []float64)
+TEXT ·axpyNEON(SB), NOSPLIT, $0-56
+	MOVD alpha+0(FP), R3
+	MOVD x_base+8(FP), R0
+	MOVD x_len+16(FP), R2
+	MOVD y_base+32(FP), R1
+	VDUP R3, V0.D2
+
+axpy_loop8:
+	CMP  $8, R2
+	BLT  axpy_loop1
+	VLD1.P 64(R0), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VLD1 (R1), [V16.D2, V17.D2, V18.D2, V19.D2]
+	VFMLA V0.D2, V4.D2, V16.D2
+	VFMLA V0.D2, V5.D2, V17.D2
+	VFMLA V0.D2, V6.D2, V18.D2
+	VFMLA V0.D2, V7.D2, V19.D2
+	VST1.P [V16.D2, V17.D2, V18.D2, V19.D2], 64(R1)
+	SUB  $8, R2
+	B    axpy_loop8
+
+axpy_loop1:
+	CBZ  R2, axpy_done
+	FMOVD.P 8(R0), F4
+	FMOVD (R1), F5
+	FMADDD F4, F5, F0, F5
+	FMOVD.P F5, 8(R1)
+	SUB  $1, R2
+	B    axpy_loop1
+
+axpy_done:
+	RET
+
+// func scaleNEON(alpha float64, x, dst []float64)
+TEXT ·scaleNEON(SB), NOSPLIT, $0-56
+	MOVD alpha+0(FP), R3
+	MOVD x_base+8(FP), R0
+	MOVD x_len+16(FP), R2
+	MOVD dst_base+32(FP), R1
+	VDUP R3, V0.D2
+
+scale_loop8:
+	CMP  $8, R2
+	BLT  scale_loop1
+	VLD1.P 64(R0), [V4.D2, V5.D2, V6.D2, V7.D2]
+	VFMUL V0.D2, V4.D2, V4.D2
+	VFMUL V0.D2, V5.D2, V5.D2
+	VFMUL V0.D2, V6.D2, V6.D2
+	VFMUL V0.D2, V7.D2, V7.D2
+	VST1.P [V4.D2, V5.D2, V6.D2, V7.D2], 64(R1)
+	SUB  $8, R2
+	B    scale_loop8
+
+scale_loop1:
+	CBZ  R2, scale_done
+	FMOVD.P 8(R0), F4
+	FMULD F0, F4, F4
+	FMOVD.P F4, 8(R1)
+	SUB  $1, R2
+	B    scale_loop1
+
+scale_done:
+	RET
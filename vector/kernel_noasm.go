@@ -0,0 +1,15 @@
+//go:build (!amd64 && !arm64) || purego
+
+package vector
+
+func dot(x, y []float64) float64 {
+	return dotGeneric(x, y)
+}
+
+func axpy(alpha float64, x, y []float64) {
+	axpyGeneric(alpha, x, y)
+}
+
+func scale(alpha float64, x, dst []float64) {
+	scaleGeneric(alpha, x, dst)
+}
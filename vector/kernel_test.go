@@ -0,0 +1,96 @@
+package vector
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func randomVector(r *rand.Rand, n int) []float64 {
+	v := make([]float64, n)
+
+	for i := range v {
+		v[i] = r.Float64()*2 - 1
+	}
+
+	return v
+}
+
+func closeTo(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol*math.Max(1, math.Abs(b))
+}
+
+func TestDotKernel(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for n := range 70 {
+		x := randomVector(r, n)
+		y := randomVector(r, n)
+
+		got := dot(x, y)
+		exp := dotGeneric(x, y)
+
+		if !closeTo(got, exp, 1e-12) {
+			t.Errorf("length %d: expected dot %f, got %f", n, exp, got)
+		}
+	}
+}
+
+func TestAxpyKernel(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+
+	for n := range 70 {
+		x := randomVector(r, n)
+		y := randomVector(r, n+1)
+		exp := append([]float64(nil), y...)
+
+		axpy(1.5, x, y)
+		axpyGeneric(1.5, x, exp)
+
+		for i := range exp {
+			if !closeTo(y[i], exp[i], 1e-14) {
+				t.Fatalf("length %d: expected y[%d] %f, got %f", n, i, exp[i], y[i])
+			}
+		}
+	}
+}
+
+func TestScaleKernel(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	for n := range 70 {
+		x := randomVector(r, n)
+		dst := make([]float64, n+1)
+		exp := make([]float64, n+1)
+		dst[n], exp[n] = 42, 42
+
+		scale(-0.75, x, dst)
+		scaleGeneric(-0.75, x, exp)
+
+		for i := range exp {
+			if dst[i] != exp[i] {
+				t.Fatalf("length %d: expected dst[%d] %f, got %f", n, i, exp[i], dst[i])
+			}
+		}
+	}
+}
+
+func BenchmarkDot(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	x := randomVector(r, 1024)
+	y := randomVector(r, 1024)
+
+	for b.Loop() {
+		_ = dot(x, y)
+	}
+}
+
+func BenchmarkDotGeneric(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+	x := randomVector(r, 1024)
+	y := randomVector(r, 1024)
+
+	for b.Loop() {
+		_ = dotGeneric(x, y)
+	}
+}
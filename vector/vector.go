@@ -1,6 +1,10 @@
 package vector
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+)
 
 func Multiply(v1, v2 []float64) (float64, error) {
 	nV := len(v1)
@@ -11,11 +15,287 @@ func Multiply(v1, v2 []float64) (float64, error) {
 			nV, nV2)
 	}
 
+	return dot(v1, v2), nil
+}
+
+func Add(v1, v2 []float64) ([]float64, error) {
+	err := checkSameLength(v1, v2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, len(v1))
+
+	for i := range v1 {
+		result[i] = v1[i] + v2[i]
+	}
+
+	return result, nil
+}
+
+func Sub(v1, v2 []float64) ([]float64, error) {
+	err := checkSameLength(v1, v2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, len(v1))
+
+	for i := range v1 {
+		result[i] = v1[i] - v2[i]
+	}
+
+	return result, nil
+}
+
+func Scale(v []float64, s float64) []float64 {
+	result := make([]float64, len(v))
+	scale(s, v, result)
+
+	return result
+}
+
+// Axpy computes y += alpha*x in place.
+func Axpy(alpha float64, x, y []float64) error {
+	err := checkSameLength(x, y)
+
+	if err != nil {
+		return err
+	}
+
+	axpy(alpha, x, y)
+
+	return nil
+}
+
+func L1Norm(v []float64) float64 {
+	sum := float64(0)
+
+	for _, x := range v {
+		sum += math.Abs(x)
+	}
+
+	return sum
+}
+
+// L2Norm returns the Euclidean length of v, scaled to avoid overflow.
+func L2Norm(v []float64) float64 {
+	scale := InfNorm(v)
+
+	if scale == 0 || math.IsInf(scale, 1) {
+		return scale
+	}
+
+	sum := float64(0)
+
+	for _, x := range v {
+		r := x / scale
+		sum += r * r
+	}
+
+	return scale * math.Sqrt(sum)
+}
+
+func InfNorm(v []float64) float64 {
+	m := float64(0)
+
+	for _, x := range v {
+		m = math.Max(m, math.Abs(x))
+	}
+
+	return m
+}
+
+func Normalize(v []float64) ([]float64, error) {
+	n := L2Norm(v)
+
+	if n == 0 {
+		return nil, errors.New("cannot normalize a zero length vector")
+	}
+
+	return Scale(v, 1/n), nil
+}
+
+func CosineSimilarity(v1, v2 []float64) (float64, error) {
+	dot, err := Multiply(v1, v2)
+
+	if err != nil {
+		return 0, err
+	}
+
+	n := L2Norm(v1) * L2Norm(v2)
+
+	if n == 0 {
+		return 0, errors.New("cosine similarity is undefined for zero length vectors")
+	}
+
+	return dot / n, nil
+}
+
+func EuclideanDistance(v1, v2 []float64) (float64, error) {
+	d, err := Sub(v1, v2)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return L2Norm(d), nil
+}
+
+func ManhattanDistance(v1, v2 []float64) (float64, error) {
+	err := checkSameLength(v1, v2)
+
+	if err != nil {
+		return 0, err
+	}
+
 	sum := float64(0)
 
 	for i := range v1 {
-		sum += v1[i] * v2[i]
+		sum += math.Abs(v1[i] - v2[i])
 	}
 
 	return sum, nil
 }
+
+func ArgMax(v []float64) (int, error) {
+	if len(v) == 0 {
+		return 0, errors.New("cannot get argmax of an empty vector")
+	}
+
+	idx := 0
+
+	for i, x := range v {
+		if x > v[idx] {
+			idx = i
+		}
+	}
+
+	return idx, nil
+}
+
+func ArgMin(v []float64) (int, error) {
+	if len(v) == 0 {
+		return 0, errors.New("cannot get argmin of an empty vector")
+	}
+
+	idx := 0
+
+	for i, x := range v {
+		if x < v[idx] {
+			idx = i
+		}
+	}
+
+	return idx, nil
+}
+
+func Sum(v []float64) float64 {
+	sum := float64(0)
+
+	for _, x := range v {
+		sum += x
+	}
+
+	return sum
+}
+
+func Mean(v []float64) (float64, error) {
+	if len(v) == 0 {
+		return 0, errors.New("cannot get mean of an empty vector")
+	}
+
+	return Sum(v) / float64(len(v)), nil
+}
+
+// Variance returns the population variance of v.
+func Variance(v []float64) (float64, error) {
+	mean, err := Mean(v)
+
+	if err != nil {
+		return 0, fmt.Errorf("cannot get variance: %w", err)
+	}
+
+	sum := float64(0)
+
+	for _, x := range v {
+		d := x - mean
+		sum += d * d
+	}
+
+	return sum / float64(len(v)), nil
+}
+
+// Softmax returns exp(v) normalized to sum to 1, shifted by the maximum so
+// large inputs do not overflow.
+func Softmax(v []float64) []float64 {
+	result := make([]float64, len(v))
+
+	if len(v) == 0 {
+		return result
+	}
+
+	m := v[0]
+	for _, x := range v {
+		m = math.Max(m, x)
+	}
+
+	sum := float64(0)
+
+	for i, x := range v {
+		result[i] = math.Exp(x - m)
+		sum += result[i]
+	}
+
+	for i := range result {
+		result[i] /= sum
+	}
+
+	return result
+}
+
+// LogSumExp returns log(sum(exp(v))) without overflow. It is -Inf for an
+// empty vector.
+func LogSumExp(v []float64) float64 {
+	if len(v) == 0 {
+		return math.Inf(-1)
+	}
+
+	m := v[0]
+	for _, x := range v {
+		m = math.Max(m, x)
+	}
+
+	if math.IsInf(m, 0) {
+		return m
+	}
+
+	sum := float64(0)
+
+	for _, x := range v {
+		sum += math.Exp(x - m)
+	}
+
+	return m + math.Log(sum)
+}
+
+func Clip(v []float64, lo, hi float64) []float64 {
+	result := make([]float64, len(v))
+
+	for i, x := range v {
+		result[i] = math.Min(math.Max(x, lo), hi)
+	}
+
+	return result
+}
+
+func checkSameLength(v1, v2 []float64) error {
+	if len(v1) != len(v2) {
+		return fmt.Errorf("vectors must be the same size - expected length %d, got %d",
+			len(v1), len(v2))
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package vector
 
 import (
+	"math"
 	"testing"
 )
 
@@ -29,3 +30,187 @@ func TestMultiplyError(t *testing.T) {
 		t.Errorf("expected error, got nil")
 	}
 }
+
+func assertVector(t *testing.T, v, exp []float64) {
+	t.Helper()
+
+	if len(v) != len(exp) {
+		t.Fatalf("expected vector length %d, got %d", len(exp), len(v))
+	}
+
+	for i := range exp {
+		if math.Abs(v[i]-exp[i]) > 1e-12 {
+			t.Errorf("expected vector[%d] %f, got %f", i, exp[i], v[i])
+		}
+	}
+}
+
+func TestAddSub(t *testing.T) {
+	v1 := []float64{2, 4, 3}
+	v2 := []float64{4, 1, 5}
+
+	v, err := Add(v1, v2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertVector(t, v, []float64{6, 5, 8})
+
+	v, err = Sub(v1, v2)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertVector(t, v, []float64{-2, 3, -2})
+
+	_, err = Add(v1, []float64{1})
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestScaleAxpy(t *testing.T) {
+	assertVector(t, Scale([]float64{1, -2}, 3), []float64{3, -6})
+
+	y := []float64{1, 1}
+
+	err := Axpy(2, []float64{1, 2}, y)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertVector(t, y, []float64{3, 5})
+}
+
+func TestNorms(t *testing.T) {
+	v := []float64{3, -4}
+
+	if n := L1Norm(v); n != 7 {
+		t.Errorf("expected L1 norm %f, got %f", float64(7), n)
+	}
+
+	if n := L2Norm(v); n != 5 {
+		t.Errorf("expected L2 norm %f, got %f", float64(5), n)
+	}
+
+	if n := InfNorm(v); n != 4 {
+		t.Errorf("expected Inf norm %f, got %f", float64(4), n)
+	}
+
+	if n := L2Norm([]float64{1e200, 1e200}); math.IsInf(n, 0) {
+		t.Errorf("expected L2 norm to avoid overflow, got %f", n)
+	}
+
+	u, err := Normalize(v)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	assertVector(t, u, []float64{0.6, -0.8})
+
+	_, err = Normalize([]float64{0, 0})
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestDistances(t *testing.T) {
+	v1 := []float64{1, 2}
+	v2 := []float64{4, 6}
+
+	d, err := EuclideanDistance(v1, v2)
+
+	if err != nil || d != 5 {
+		t.Errorf("expected euclidean distance %f, got %f (%v)", float64(5), d, err)
+	}
+
+	d, err = ManhattanDistance(v1, v2)
+
+	if err != nil || d != 7 {
+		t.Errorf("expected manhattan distance %f, got %f (%v)", float64(7), d, err)
+	}
+
+	c, err := CosineSimilarity([]float64{1, 0}, []float64{0, 2})
+
+	if err != nil || c != 0 {
+		t.Errorf("expected cosine similarity %f, got %f (%v)", float64(0), c, err)
+	}
+
+	_, err = CosineSimilarity([]float64{0, 0}, []float64{1, 2})
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestArgMaxArgMin(t *testing.T) {
+	v := []float64{2, 7, -1, 7}
+
+	if i, _ := ArgMax(v); i != 1 {
+		t.Errorf("expected argmax %d, got %d", 1, i)
+	}
+
+	if i, _ := ArgMin(v); i != 2 {
+		t.Errorf("expected argmin %d, got %d", 2, i)
+	}
+
+	_, err := ArgMax(nil)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	v := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	if s := Sum(v); s != 40 {
+		t.Errorf("expected sum %f, got %f", float64(40), s)
+	}
+
+	mean, err := Mean(v)
+
+	if err != nil || mean != 5 {
+		t.Errorf("expected mean %f, got %f (%v)", float64(5), mean, err)
+	}
+
+	variance, err := Variance(v)
+
+	if err != nil || variance != 4 {
+		t.Errorf("expected variance %f, got %f (%v)", float64(4), variance, err)
+	}
+
+	_, err = Variance(nil)
+
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestSoftmaxLogSumExp(t *testing.T) {
+	s := Softmax([]float64{1000, 1000})
+
+	assertVector(t, s, []float64{0.5, 0.5})
+
+	s = Softmax([]float64{0, math.Log(3)})
+
+	assertVector(t, s, []float64{0.25, 0.75})
+
+	if l := LogSumExp([]float64{1000, 1000}); math.Abs(l-(1000+math.Ln2)) > 1e-9 {
+		t.Errorf("expected logsumexp %f, got %f", 1000+math.Ln2, l)
+	}
+
+	if l := LogSumExp(nil); !math.IsInf(l, -1) {
+		t.Errorf("expected logsumexp -Inf, got %f", l)
+	}
+}
+
+func TestClip(t *testing.T) {
+	assertVector(t, Clip([]float64{-2, 0.5, 3}, -1, 1), []float64{-1, 0.5, 1})
+}
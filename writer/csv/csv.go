@@ -0,0 +1,164 @@
+package csv
+
+import (
+	"bufio"
+	"fmt"
+	"gonn/datatable"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type QuoteMode int
+
+const (
+	// QuoteMinimal quotes only fields containing the separator, a quote, a
+	// line break or leading space.
+	QuoteMinimal QuoteMode = iota
+	QuoteAll
+	// QuoteNonNumeric quotes text, labels and timestamps, and any field
+	// QuoteMinimal would.
+	QuoteNonNumeric
+)
+
+type TableWriter struct {
+	Separator rune
+	Header    bool
+	Quote     QuoteMode
+
+	// FloatFormat and Precision are passed to strconv.FormatFloat for
+	// Float columns
+	FloatFormat byte
+	Precision   int
+
+	// NA is written for missing values
+	NA string
+}
+
+// NewWriter returns a writer with a header row, minimal quoting and the
+// shortest float format that reads back exactly. Use '\t' for TSV.
+func NewWriter(separator rune) *TableWriter {
+	return &TableWriter{
+		Separator:   separator,
+		Header:      true,
+		Quote:       QuoteMinimal,
+		FloatFormat: 'g',
+		Precision:   -1,
+	}
+}
+
+func (tw *TableWriter) WriteFile(path string, d *datatable.DataTable) (err error) {
+	f, err := os.Create(path)
+
+	if err != nil {
+		return fmt.Errorf("failed to create file path: %s, with error: %w", path, err)
+	}
+
+	defer func() {
+		closeErr := f.Close()
+
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to close file path: %s, with error: %w", path, closeErr)
+		}
+	}()
+
+	return tw.Write(f, d)
+}
+
+func (tw *TableWriter) Write(w io.Writer, d *datatable.DataTable) error {
+	if tw.Separator == '"' || tw.Separator == '\r' || tw.Separator == '\n' {
+		return fmt.Errorf("invalid separator %q", tw.Separator)
+	}
+
+	bw := bufio.NewWriter(w)
+	fields := make([]string, len(d.Cols))
+	quoted := make([]bool, len(d.Cols))
+
+	if tw.Header {
+		for i, name := range d.Cols {
+			fields[i] = name
+			quoted[i] = tw.Quote == QuoteNonNumeric
+		}
+
+		tw.writeLine(bw, fields, quoted)
+	}
+
+	for row := range d.Matrix.Rows {
+		for col := range d.Cols {
+			v, err := d.ValueAt(row, col)
+
+			if err != nil {
+				return fmt.Errorf("failed to write row %d: %w", row, err)
+			}
+
+			fields[col], quoted[col] = tw.format(v)
+		}
+
+		tw.writeLine(bw, fields, quoted)
+	}
+
+	err := bw.Flush()
+
+	if err != nil {
+		return fmt.Errorf("failed to write table: %w", err)
+	}
+
+	return nil
+}
+
+// format returns the text of a value and whether QuoteNonNumeric quotes it.
+func (tw *TableWriter) format(v any) (string, bool) {
+	switch x := v.(type) {
+	case nil:
+		return tw.NA, false
+	case float64:
+		return strconv.FormatFloat(x, tw.FloatFormat, tw.Precision, 64), false
+	case int64:
+		return strconv.FormatInt(x, 10), false
+	case bool:
+		return strconv.FormatBool(x), false
+	case string:
+		return x, true
+	case time.Time:
+		return x.Format(time.RFC3339Nano), true
+	default:
+		return fmt.Sprint(x), true
+	}
+}
+
+// bufio.Writer keeps the first write error and reports it from Flush
+func (tw *TableWriter) writeLine(bw *bufio.Writer, fields []string, nonNumeric []bool) {
+	for i, f := range fields {
+		if i > 0 {
+			_, _ = bw.WriteRune(tw.Separator)
+		}
+
+		if tw.needsQuotes(f, nonNumeric[i]) {
+			_ = bw.WriteByte('"')
+			_, _ = bw.WriteString(strings.ReplaceAll(f, `"`, `""`))
+			_ = bw.WriteByte('"')
+
+			continue
+		}
+
+		_, _ = bw.WriteString(f)
+	}
+
+	_ = bw.WriteByte('\n')
+}
+
+func (tw *TableWriter) needsQuotes(f string, nonNumeric bool) bool {
+	switch {
+	case tw.Quote == QuoteAll:
+		return true
+	case tw.Quote == QuoteNonNumeric && nonNumeric:
+		return true
+	case f == "":
+		return false
+	}
+
+	return f[0] == ' ' || f[0] == '\t' ||
+		strings.ContainsRune(f, tw.Separator) || strings.ContainsAny(f, "\"\r\n")
+}
@@ -0,0 +1,137 @@
+package csv
+
+import (
+	"bytes"
+	"gonn/datatable"
+	reader "gonn/reader/csv"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTable builds a table with the given schema from rows of Go values, as
+// AddRecord takes them.
+func newTable(t *testing.T, schema datatable.Schema, rows [][]any) *datatable.DataTable {
+	t.Helper()
+
+	d := datatable.NewDataTableWithSchema(schema)
+
+	for _, r := range rows {
+		err := d.AddRecord(r...)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	return d
+}
+
+// testSchema and testRows hold a missing value and a cell that needs
+// quoting.
+var testSchema = datatable.Schema{
+	{Name: "x", Type: datatable.Float},
+	{Name: "n", Type: datatable.Int},
+	{Name: "note", Type: datatable.String},
+	{Name: "seen", Type: datatable.Timestamp},
+}
+
+var testRows = [][]any{
+	{0.1, 3, "plain", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	{nil, -1, `say "hi", bye`, nil},
+}
+
+func TestWrite(t *testing.T) {
+	d := newTable(t, testSchema, testRows)
+
+	tests := []struct {
+		configure func(tw *TableWriter)
+		exp       string
+	}{
+		{
+			func(tw *TableWriter) {},
+			"x,n,note,seen\n" +
+				"0.1,3,plain,2024-01-02T03:04:05Z\n" +
+				",-1,\"say \"\"hi\"\", bye\",\n",
+		},
+		{
+			func(tw *TableWriter) {
+				tw.Separator = '\t'
+				tw.Header = false
+				tw.FloatFormat = 'f'
+				tw.Precision = 3
+				tw.NA = "NA"
+			},
+			"0.100\t3\tplain\t2024-01-02T03:04:05Z\n" +
+				"NA\t-1\t\"say \"\"hi\"\", bye\"\tNA\n",
+		},
+		{
+			func(tw *TableWriter) {
+				tw.Header = false
+				tw.Quote = QuoteNonNumeric
+			},
+			"0.1,3,\"plain\",\"2024-01-02T03:04:05Z\"\n" +
+				",-1,\"say \"\"hi\"\", bye\",\n",
+		},
+	}
+
+	for i, tt := range tests {
+		tw := NewWriter(',')
+		tt.configure(tw)
+
+		var buf bytes.Buffer
+
+		err := tw.Write(&buf, d)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if buf.String() != tt.exp {
+			t.Errorf("case %d: expected\n%q\ngot\n%q", i, tt.exp, buf.String())
+		}
+	}
+}
+
+func TestWriteFileRoundTrip(t *testing.T) {
+	d := newTable(t, testSchema, testRows)
+	path := filepath.Join(t.TempDir(), "out.csv")
+
+	err := NewWriter(',').WriteFile(path, d)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tr := reader.NewReader(path, true, ',')
+	tr.NATokens = reader.DefaultNATokens
+
+	for i, f := range d.Schema() {
+		tr.DefineTypedColumn(i, f.Name, f.Type)
+	}
+
+	err = tr.ReadTable()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for row := range d.Matrix.Rows {
+		for col := range d.Cols {
+			exp, _ := d.ValueAt(row, col)
+			got, _ := tr.DataTable.ValueAt(row, col)
+
+			if e, ok := exp.(time.Time); ok {
+				if !e.Equal(got.(time.Time)) {
+					t.Errorf("expected %v, got %v", exp, got)
+				}
+
+				continue
+			}
+
+			if got != exp {
+				t.Errorf("expected %v at row %d column %d, got %v", exp, row, col, got)
+			}
+		}
+	}
+}
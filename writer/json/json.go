@@ -0,0 +1,160 @@
+package json
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"gonn/datatable"
+	"io"
+	"math"
+)
+
+type Orient int
+
+const (
+	// Records writes an array with one object per row.
+	Records Orient = iota
+	// Columns writes one object with an array of values per column.
+	Columns
+)
+
+// Write writes d as a JSON document in the given orientation. Objects keep
+// the column order of the table and missing values are written as null.
+// JSON has no infinite numbers, so ±Inf is written as the string
+// "Infinity" or "-Infinity".
+func Write(w io.Writer, d *datatable.DataTable, orient Orient) error {
+	bw := bufio.NewWriter(w)
+	enc := encoder{w: bw}
+
+	switch orient {
+	case Records:
+		enc.raw("[")
+
+		for row := range d.Matrix.Rows {
+			if row > 0 {
+				enc.raw(",")
+			}
+
+			enc.record(d, row)
+		}
+
+		enc.raw("]\n")
+	case Columns:
+		enc.raw("{")
+
+		for col, name := range d.Cols {
+			if col > 0 {
+				enc.raw(",")
+			}
+
+			enc.value(name)
+			enc.raw(":[")
+
+			for row := range d.Matrix.Rows {
+				if row > 0 {
+					enc.raw(",")
+				}
+
+				enc.cell(d, row, col)
+			}
+
+			enc.raw("]")
+		}
+
+		enc.raw("}\n")
+	default:
+		return fmt.Errorf("unknown json orientation %d", orient)
+	}
+
+	return enc.flush(bw)
+}
+
+// WriteLines writes d as JSON Lines, one record object per line.
+func WriteLines(w io.Writer, d *datatable.DataTable) error {
+	bw := bufio.NewWriter(w)
+	enc := encoder{w: bw}
+
+	for row := range d.Matrix.Rows {
+		enc.record(d, row)
+		enc.raw("\n")
+	}
+
+	return enc.flush(bw)
+}
+
+// encoder keeps the first error so the writers can emit tokens freely.
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) raw(s string) {
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
+
+func (e *encoder) value(v any) {
+	if e.err != nil {
+		return
+	}
+
+	if f, ok := v.(float64); ok && math.IsInf(f, 0) {
+		v = "Infinity"
+		if f < 0 {
+			v = "-Infinity"
+		}
+	}
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		e.err = err
+		return
+	}
+
+	_, e.err = e.w.Write(b)
+}
+
+func (e *encoder) cell(d *datatable.DataTable, row, col int) {
+	if e.err != nil {
+		return
+	}
+
+	v, err := d.ValueAt(row, col)
+
+	if err != nil {
+		e.err = err
+		return
+	}
+
+	e.value(v)
+}
+
+func (e *encoder) record(d *datatable.DataTable, row int) {
+	e.raw("{")
+
+	for col, name := range d.Cols {
+		if col > 0 {
+			e.raw(",")
+		}
+
+		e.value(name)
+		e.raw(":")
+		e.cell(d, row, col)
+	}
+
+	e.raw("}")
+}
+
+func (e *encoder) flush(bw *bufio.Writer) error {
+	if e.err == nil {
+		e.err = bw.Flush()
+	}
+
+	if e.err != nil {
+		return fmt.Errorf("failed to write json: %w", e.err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package json
+
+import (
+	"bytes"
+	"gonn/datatable"
+	"math"
+	"testing"
+)
+
+// newTable builds a table with the given schema from rows of Go values, as
+// AddRecord takes them.
+func newTable(t *testing.T, schema datatable.Schema, rows [][]any) *datatable.DataTable {
+	t.Helper()
+
+	d := datatable.NewDataTableWithSchema(schema)
+
+	for _, r := range rows {
+		err := d.AddRecord(r...)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	return d
+}
+
+// testSchema and testRows hold a missing value and a categorical column.
+var testSchema = datatable.Schema{
+	{Name: "x", Type: datatable.Float},
+	{Name: "ok", Type: datatable.Bool},
+	{Name: "variety", Type: datatable.Categorical},
+}
+
+var testRows = [][]any{{1.5, true, "Setosa"}, {nil, false, "Virginica"}}
+
+func TestWrite(t *testing.T) {
+	d := newTable(t, testSchema, testRows)
+
+	tests := []struct {
+		orient Orient
+		exp    string
+	}{
+		{Records, `[{"x":1.5,"ok":true,"variety":"Setosa"},{"x":null,"ok":false,"variety":"Virginica"}]` + "\n"},
+		{Columns, `{"x":[1.5,null],"ok":[true,false],"variety":["Setosa","Virginica"]}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+
+		err := Write(&buf, d, tt.orient)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if buf.String() != tt.exp {
+			t.Errorf("expected\n%s\ngot\n%s", tt.exp, buf.String())
+		}
+	}
+
+	err := Write(&bytes.Buffer{}, d, Orient(7))
+
+	if err == nil {
+		t.Errorf("expected error for unknown orientation, got nil")
+	}
+}
+
+func TestWriteLines(t *testing.T) {
+	d := newTable(t, testSchema, testRows)
+
+	var buf bytes.Buffer
+
+	err := WriteLines(&buf, d)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := `{"x":1.5,"ok":true,"variety":"Setosa"}` + "\n" +
+		`{"x":null,"ok":false,"variety":"Virginica"}` + "\n"
+
+	if buf.String() != exp {
+		t.Errorf("expected\n%s\ngot\n%s", exp, buf.String())
+	}
+}
+
+func TestWriteInfinite(t *testing.T) {
+	d := datatable.NewDataTable([]string{"x"})
+	_ = d.AddRow([]float64{math.Inf(1)})
+	_ = d.AddRow([]float64{math.Inf(-1)})
+	_ = d.AddRow([]float64{math.NaN()})
+
+	var buf bytes.Buffer
+
+	err := WriteLines(&buf, d)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	exp := "{\"x\":\"Infinity\"}\n{\"x\":\"-Infinity\"}\n{\"x\":null}\n"
+
+	if buf.String() != exp {
+		t.Errorf("expected %q, got %q", exp, buf.String())
+	}
+}